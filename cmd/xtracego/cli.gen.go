@@ -47,37 +47,61 @@ func Run(handler CLIHandler, args []string) error {
 }
 
 type Input struct {
-	Opt_CopyOnly    []string
-	Opt_CopyOnlyNot string
-	Opt_Goroutine   bool
-	Opt_Help        bool
-	Opt_Seed        int64
-	Opt_Timestamp   bool
-	Opt_TraceCall   bool
-	Opt_TraceStmt   bool
-	Opt_TraceVar    bool
-	Opt_Verbose     bool
-	Subcommand      []string
-	Options         []string
-	Arguments       []string
+	Opt_CopyOnly          []string
+	Opt_CopyOnlyNot       string
+	Opt_ExcludeFunc       []string
+	Opt_Format            string
+	Opt_Goroutine         bool
+	Opt_Help              bool
+	Opt_IncludeFunc       []string
+	Opt_Indent            bool
+	Opt_Output            string
+	Opt_Seed              int64
+	Opt_Timestamp         bool
+	Opt_TraceCall         bool
+	Opt_TraceChan         bool
+	Opt_TraceCond         bool
+	Opt_TraceDep          []string
+	Opt_TraceErrorsOnly   bool
+	Opt_TraceExternCalls  bool
+	Opt_TraceLocalModules bool
+	Opt_TracePkg          []string
+	Opt_TraceStmt         bool
+	Opt_TraceVar          bool
+	Opt_Verbose           bool
+	Subcommand            []string
+	Options               []string
+	Arguments             []string
 
 	ErrorMessage string
 }
 
 func (input *Input) resolveInput(subcommand, options, arguments []string) {
 	*input = Input{Opt_CopyOnly: []string{},
-		Opt_CopyOnlyNot: ".*",
-		Opt_Goroutine:   true,
-		Opt_Help:        false,
-		Opt_Seed:        0,
-		Opt_Timestamp:   true,
-		Opt_TraceCall:   true,
-		Opt_TraceStmt:   true,
-		Opt_TraceVar:    true,
-		Opt_Verbose:     false,
-		Subcommand:      subcommand,
-		Options:         options,
-		Arguments:       arguments,
+		Opt_CopyOnlyNot:       ".*",
+		Opt_ExcludeFunc:       []string{},
+		Opt_Format:            "text",
+		Opt_Goroutine:         true,
+		Opt_Help:              false,
+		Opt_IncludeFunc:       []string{},
+		Opt_Indent:            false,
+		Opt_Output:            "",
+		Opt_Seed:              0,
+		Opt_Timestamp:         true,
+		Opt_TraceCall:         true,
+		Opt_TraceChan:         false,
+		Opt_TraceCond:         false,
+		Opt_TraceDep:          []string{},
+		Opt_TraceErrorsOnly:   false,
+		Opt_TraceExternCalls:  false,
+		Opt_TraceLocalModules: false,
+		Opt_TracePkg:          []string{},
+		Opt_TraceStmt:         true,
+		Opt_TraceVar:          true,
+		Opt_Verbose:           false,
+		Subcommand:            subcommand,
+		Options:               options,
+		Arguments:             arguments,
 	}
 
 	for _, arg := range input.Options {
@@ -109,6 +133,30 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.Opt_CopyOnlyNot = v.(string)
 			}
 
+		case "-exclude-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_ExcludeFunc = append(input.Opt_ExcludeFunc, v.([]string)[0])
+			}
+
+		case "-format":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Format = v.(string)
+			}
+
 		case "-goroutine":
 			if !cut {
 				lit = "true"
@@ -141,19 +189,19 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.Opt_Help = v.(bool)
 			}
 
-		case "-seed":
+		case "-include-func":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
 			}
-			if v, err := parseValue("int64", lit); err != nil {
+			if v, err := parseValue("[]string", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Seed = v.(int64)
+				input.Opt_IncludeFunc = append(input.Opt_IncludeFunc, v.([]string)[0])
 			}
 
-		case "-timestamp":
+		case "-indent":
 			if !cut {
 				lit = "true"
 			}
@@ -161,9 +209,9 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = v.(bool)
+				input.Opt_Indent = v.(bool)
 			}
-		case "-no-timestamp":
+		case "-no-indent":
 			if !cut {
 				lit = "true"
 			}
@@ -171,31 +219,34 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = !v.(bool)
+				input.Opt_Indent = !v.(bool)
 			}
 
-		case "-trace-call":
+		case "-output":
 			if !cut {
-				lit = "true"
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
 			}
-			if v, err := parseValue("bool", lit); err != nil {
+			if v, err := parseValue("string", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceCall = v.(bool)
+				input.Opt_Output = v.(string)
 			}
-		case "-no-trace-call":
+
+		case "-seed":
 			if !cut {
-				lit = "true"
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
 			}
-			if v, err := parseValue("bool", lit); err != nil {
+			if v, err := parseValue("int64", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceCall = !v.(bool)
+				input.Opt_Seed = v.(int64)
 			}
 
-		case "-trace-stmt":
+		case "-timestamp":
 			if !cut {
 				lit = "true"
 			}
@@ -203,9 +254,9 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceStmt = v.(bool)
+				input.Opt_Timestamp = v.(bool)
 			}
-		case "-no-trace-stmt":
+		case "-no-timestamp":
 			if !cut {
 				lit = "true"
 			}
@@ -213,10 +264,10 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceStmt = !v.(bool)
+				input.Opt_Timestamp = !v.(bool)
 			}
 
-		case "-trace-var":
+		case "-trace-call":
 			if !cut {
 				lit = "true"
 			}
@@ -224,9 +275,9 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceVar = v.(bool)
+				input.Opt_TraceCall = v.(bool)
 			}
-		case "-no-trace-var":
+		case "-no-trace-call":
 			if !cut {
 				lit = "true"
 			}
@@ -234,10 +285,10 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceVar = !v.(bool)
+				input.Opt_TraceCall = !v.(bool)
 			}
 
-		case "-verbose", "-v":
+		case "-trace-chan":
 			if !cut {
 				lit = "true"
 			}
@@ -245,100 +296,41 @@ func (input *Input) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Verbose = v.(bool)
+				input.Opt_TraceChan = v.(bool)
 			}
-
-		default:
-			input.ErrorMessage = fmt.Sprintf("unknown option %q", optName)
-			return
-		}
-	}
-
-	expectedArgs := 0
-	func(...any) {}(expectedArgs)
-}
-
-type Input_Build struct {
-	Opt_BuildDirectory string
-	Opt_CopyOnly       []string
-	Opt_CopyOnlyNot    string
-	Opt_GoBuildArg     []string
-	Opt_Goroutine      bool
-	Opt_Help           bool
-	Opt_Seed           int64
-	Opt_Timestamp      bool
-	Opt_TraceCall      bool
-	Opt_TraceStmt      bool
-	Opt_TraceVar       bool
-	Opt_Verbose        bool
-	Arg_Package        string
-	Subcommand         []string
-	Options            []string
-	Arguments          []string
-
-	ErrorMessage string
-}
-
-func (input *Input_Build) resolveInput(subcommand, options, arguments []string) {
-	*input = Input_Build{Opt_BuildDirectory: "",
-		Opt_CopyOnly:    []string{},
-		Opt_CopyOnlyNot: ".*",
-		Opt_GoBuildArg:  []string{},
-		Opt_Goroutine:   true,
-		Opt_Help:        false,
-		Opt_Seed:        0,
-		Opt_Timestamp:   true,
-		Opt_TraceCall:   true,
-		Opt_TraceStmt:   true,
-		Opt_TraceVar:    true,
-		Opt_Verbose:     false,
-		Subcommand:      subcommand,
-		Options:         options,
-		Arguments:       arguments,
-	}
-
-	for _, arg := range input.Options {
-		optName, lit, cut := strings.Cut(arg, "=")
-		func(...any) {}(optName, lit, cut)
-
-		switch optName {
-		case "-build-directory", "-o":
+		case "-no-trace-chan":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("string", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_BuildDirectory = v.(string)
+				input.Opt_TraceChan = !v.(bool)
 			}
 
-		case "-copy-only":
+		case "-trace-cond":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("[]string", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_CopyOnly = append(input.Opt_CopyOnly, v.([]string)[0])
+				input.Opt_TraceCond = v.(bool)
 			}
-
-		case "-copy-only-not":
+		case "-no-trace-cond":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("string", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_CopyOnlyNot = v.(string)
+				input.Opt_TraceCond = !v.(bool)
 			}
 
-		case "-go-build-arg", "-a":
+		case "-trace-dep":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
@@ -347,10 +339,10 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_GoBuildArg = append(input.Opt_GoBuildArg, v.([]string)[0])
+				input.Opt_TraceDep = append(input.Opt_TraceDep, v.([]string)[0])
 			}
 
-		case "-goroutine":
+		case "-trace-errors-only":
 			if !cut {
 				lit = "true"
 			}
@@ -358,9 +350,9 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Goroutine = v.(bool)
+				input.Opt_TraceErrorsOnly = v.(bool)
 			}
-		case "-no-goroutine":
+		case "-no-trace-errors-only":
 			if !cut {
 				lit = "true"
 			}
@@ -368,10 +360,10 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Goroutine = !v.(bool)
+				input.Opt_TraceErrorsOnly = !v.(bool)
 			}
 
-		case "-help", "-h":
+		case "-trace-extern-calls":
 			if !cut {
 				lit = "true"
 			}
@@ -379,22 +371,20 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Help = v.(bool)
+				input.Opt_TraceExternCalls = v.(bool)
 			}
-
-		case "-seed":
+		case "-no-trace-extern-calls":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("int64", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Seed = v.(int64)
+				input.Opt_TraceExternCalls = !v.(bool)
 			}
 
-		case "-timestamp":
+		case "-trace-local-modules":
 			if !cut {
 				lit = "true"
 			}
@@ -402,9 +392,9 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = v.(bool)
+				input.Opt_TraceLocalModules = v.(bool)
 			}
-		case "-no-timestamp":
+		case "-no-trace-local-modules":
 			if !cut {
 				lit = "true"
 			}
@@ -412,28 +402,19 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = !v.(bool)
+				input.Opt_TraceLocalModules = !v.(bool)
 			}
 
-		case "-trace-call":
+		case "-trace-pkg":
 			if !cut {
-				lit = "true"
-			}
-			if v, err := parseValue("bool", lit); err != nil {
-				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
-			} else {
-				input.Opt_TraceCall = v.(bool)
-			}
-		case "-no-trace-call":
-			if !cut {
-				lit = "true"
 			}
-			if v, err := parseValue("bool", lit); err != nil {
+			if v, err := parseValue("[]string", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceCall = !v.(bool)
+				input.Opt_TracePkg = append(input.Opt_TracePkg, v.([]string)[0])
 			}
 
 		case "-trace-stmt":
@@ -495,55 +476,75 @@ func (input *Input_Build) resolveInput(subcommand, options, arguments []string)
 		}
 	}
 
-	expectedArgs := 1
+	expectedArgs := 0
 	func(...any) {}(expectedArgs)
-	if len(input.Arguments) <= 0 {
-		input.ErrorMessage = fmt.Sprintf("too few arguments: required %d, got %d", expectedArgs, len(input.Arguments))
-		return
-	}
-	if v, err := parseValue("string", input.Arguments[0:]...); err != nil {
-		input.ErrorMessage = fmt.Sprintf("value %q is not assignable to argument at [%d]", input.Arguments[0], 0)
-		return
-	} else {
-		input.Arg_Package = v.(string)
-	}
 }
 
-type Input_Rewrite struct {
-	Opt_CopyOnly        []string
-	Opt_CopyOnlyNot     string
-	Opt_Goroutine       bool
-	Opt_Help            bool
-	Opt_OutputDirectory string
-	Opt_Seed            int64
-	Opt_Timestamp       bool
-	Opt_TraceCall       bool
-	Opt_TraceStmt       bool
-	Opt_TraceVar        bool
-	Opt_Verbose         bool
-	Arg_Package         string
-	Subcommand          []string
-	Options             []string
-	Arguments           []string
+type Input_Build struct {
+	Opt_BuildDirectory    string
+	Opt_CopyOnly          []string
+	Opt_CopyOnlyNot       string
+	Opt_ExcludeFunc       []string
+	Opt_Format            string
+	Opt_GoBuildArg        []string
+	Opt_Goroutine         bool
+	Opt_Help              bool
+	Opt_IncludeFunc       []string
+	Opt_Indent            bool
+	Opt_Mod               string
+	Opt_Output            string
+	Opt_Overlay           bool
+	Opt_Seed              int64
+	Opt_Timestamp         bool
+	Opt_TraceCall         bool
+	Opt_TraceChan         bool
+	Opt_TraceCond         bool
+	Opt_TraceDep          []string
+	Opt_TraceErrorsOnly   bool
+	Opt_TraceExternCalls  bool
+	Opt_TraceLocalModules bool
+	Opt_TracePkg          []string
+	Opt_TraceStmt         bool
+	Opt_TraceVar          bool
+	Opt_Verbose           bool
+	Arg_Package           string
+	Subcommand            []string
+	Options               []string
+	Arguments             []string
 
 	ErrorMessage string
 }
 
-func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string) {
-	*input = Input_Rewrite{Opt_CopyOnly: []string{},
-		Opt_CopyOnlyNot:     ".*",
-		Opt_Goroutine:       true,
-		Opt_Help:            false,
-		Opt_OutputDirectory: "",
-		Opt_Seed:            0,
-		Opt_Timestamp:       true,
-		Opt_TraceCall:       true,
-		Opt_TraceStmt:       true,
-		Opt_TraceVar:        true,
-		Opt_Verbose:         false,
-		Subcommand:          subcommand,
-		Options:             options,
-		Arguments:           arguments,
+func (input *Input_Build) resolveInput(subcommand, options, arguments []string) {
+	*input = Input_Build{Opt_BuildDirectory: "",
+		Opt_CopyOnly:          []string{},
+		Opt_CopyOnlyNot:       ".*",
+		Opt_ExcludeFunc:       []string{},
+		Opt_Format:            "text",
+		Opt_GoBuildArg:        []string{},
+		Opt_Goroutine:         true,
+		Opt_Help:              false,
+		Opt_IncludeFunc:       []string{},
+		Opt_Indent:            false,
+		Opt_Mod:               "",
+		Opt_Output:            "",
+		Opt_Overlay:           false,
+		Opt_Seed:              0,
+		Opt_Timestamp:         true,
+		Opt_TraceCall:         true,
+		Opt_TraceChan:         false,
+		Opt_TraceCond:         false,
+		Opt_TraceDep:          []string{},
+		Opt_TraceErrorsOnly:   false,
+		Opt_TraceExternCalls:  false,
+		Opt_TraceLocalModules: false,
+		Opt_TracePkg:          []string{},
+		Opt_TraceStmt:         true,
+		Opt_TraceVar:          true,
+		Opt_Verbose:           false,
+		Subcommand:            subcommand,
+		Options:               options,
+		Arguments:             arguments,
 	}
 
 	for _, arg := range input.Options {
@@ -551,6 +552,18 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 		func(...any) {}(optName, lit, cut)
 
 		switch optName {
+		case "-build-directory", "-o":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_BuildDirectory = v.(string)
+			}
+
 		case "-copy-only":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
@@ -575,6 +588,42 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.Opt_CopyOnlyNot = v.(string)
 			}
 
+		case "-exclude-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_ExcludeFunc = append(input.Opt_ExcludeFunc, v.([]string)[0])
+			}
+
+		case "-format":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Format = v.(string)
+			}
+
+		case "-go-build-arg", "-a":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_GoBuildArg = append(input.Opt_GoBuildArg, v.([]string)[0])
+			}
+
 		case "-goroutine":
 			if !cut {
 				lit = "true"
@@ -607,7 +656,40 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.Opt_Help = v.(bool)
 			}
 
-		case "-output-directory", "-o":
+		case "-include-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_IncludeFunc = append(input.Opt_IncludeFunc, v.([]string)[0])
+			}
+
+		case "-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = v.(bool)
+			}
+		case "-no-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = !v.(bool)
+			}
+
+		case "-mod":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
@@ -616,7 +698,30 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_OutputDirectory = v.(string)
+				input.Opt_Mod = v.(string)
+			}
+
+		case "-output":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Output = v.(string)
+			}
+
+		case "-overlay":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Overlay = v.(bool)
 			}
 
 		case "-seed":
@@ -673,7 +778,595 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.Opt_TraceCall = !v.(bool)
 			}
 
-		case "-trace-stmt":
+		case "-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = v.(bool)
+			}
+		case "-no-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = !v.(bool)
+			}
+
+		case "-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = v.(bool)
+			}
+		case "-no-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = !v.(bool)
+			}
+
+		case "-trace-dep":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceDep = append(input.Opt_TraceDep, v.([]string)[0])
+			}
+
+		case "-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = v.(bool)
+			}
+		case "-no-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = !v.(bool)
+			}
+
+		case "-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = v.(bool)
+			}
+		case "-no-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = !v.(bool)
+			}
+
+		case "-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = v.(bool)
+			}
+		case "-no-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = !v.(bool)
+			}
+
+		case "-trace-pkg":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TracePkg = append(input.Opt_TracePkg, v.([]string)[0])
+			}
+
+		case "-trace-stmt":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceStmt = v.(bool)
+			}
+		case "-no-trace-stmt":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceStmt = !v.(bool)
+			}
+
+		case "-trace-var":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceVar = v.(bool)
+			}
+		case "-no-trace-var":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceVar = !v.(bool)
+			}
+
+		case "-verbose", "-v":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Verbose = v.(bool)
+			}
+
+		default:
+			input.ErrorMessage = fmt.Sprintf("unknown option %q", optName)
+			return
+		}
+	}
+
+	expectedArgs := 1
+	func(...any) {}(expectedArgs)
+	if len(input.Arguments) <= 0 {
+		input.ErrorMessage = fmt.Sprintf("too few arguments: required %d, got %d", expectedArgs, len(input.Arguments))
+		return
+	}
+	if v, err := parseValue("string", input.Arguments[0:]...); err != nil {
+		input.ErrorMessage = fmt.Sprintf("value %q is not assignable to argument at [%d]", input.Arguments[0], 0)
+		return
+	} else {
+		input.Arg_Package = v.(string)
+	}
+}
+
+type Input_Rewrite struct {
+	Opt_CopyOnly          []string
+	Opt_CopyOnlyNot       string
+	Opt_ExcludeFunc       []string
+	Opt_Format            string
+	Opt_Goroutine         bool
+	Opt_Help              bool
+	Opt_IncludeFunc       []string
+	Opt_Indent            bool
+	Opt_Output            string
+	Opt_OutputDirectory   string
+	Opt_Seed              int64
+	Opt_Timestamp         bool
+	Opt_TraceCall         bool
+	Opt_TraceChan         bool
+	Opt_TraceCond         bool
+	Opt_TraceDep          []string
+	Opt_TraceErrorsOnly   bool
+	Opt_TraceExternCalls  bool
+	Opt_TraceLocalModules bool
+	Opt_TracePkg          []string
+	Opt_TraceStmt         bool
+	Opt_TraceVar          bool
+	Opt_Verbose           bool
+	Arg_Package           string
+	Subcommand            []string
+	Options               []string
+	Arguments             []string
+
+	ErrorMessage string
+}
+
+func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string) {
+	*input = Input_Rewrite{Opt_CopyOnly: []string{},
+		Opt_CopyOnlyNot:       ".*",
+		Opt_ExcludeFunc:       []string{},
+		Opt_Format:            "text",
+		Opt_Goroutine:         true,
+		Opt_Help:              false,
+		Opt_IncludeFunc:       []string{},
+		Opt_Indent:            false,
+		Opt_Output:            "",
+		Opt_OutputDirectory:   "",
+		Opt_Seed:              0,
+		Opt_Timestamp:         true,
+		Opt_TraceCall:         true,
+		Opt_TraceChan:         false,
+		Opt_TraceCond:         false,
+		Opt_TraceDep:          []string{},
+		Opt_TraceErrorsOnly:   false,
+		Opt_TraceExternCalls:  false,
+		Opt_TraceLocalModules: false,
+		Opt_TracePkg:          []string{},
+		Opt_TraceStmt:         true,
+		Opt_TraceVar:          true,
+		Opt_Verbose:           false,
+		Subcommand:            subcommand,
+		Options:               options,
+		Arguments:             arguments,
+	}
+
+	for _, arg := range input.Options {
+		optName, lit, cut := strings.Cut(arg, "=")
+		func(...any) {}(optName, lit, cut)
+
+		switch optName {
+		case "-copy-only":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_CopyOnly = append(input.Opt_CopyOnly, v.([]string)[0])
+			}
+
+		case "-copy-only-not":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_CopyOnlyNot = v.(string)
+			}
+
+		case "-exclude-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_ExcludeFunc = append(input.Opt_ExcludeFunc, v.([]string)[0])
+			}
+
+		case "-format":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Format = v.(string)
+			}
+
+		case "-goroutine":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Goroutine = v.(bool)
+			}
+		case "-no-goroutine":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Goroutine = !v.(bool)
+			}
+
+		case "-help", "-h":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Help = v.(bool)
+			}
+
+		case "-include-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_IncludeFunc = append(input.Opt_IncludeFunc, v.([]string)[0])
+			}
+
+		case "-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = v.(bool)
+			}
+		case "-no-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = !v.(bool)
+			}
+
+		case "-output":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Output = v.(string)
+			}
+
+		case "-output-directory", "-o":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_OutputDirectory = v.(string)
+			}
+
+		case "-seed":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("int64", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Seed = v.(int64)
+			}
+
+		case "-timestamp":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Timestamp = v.(bool)
+			}
+		case "-no-timestamp":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Timestamp = !v.(bool)
+			}
+
+		case "-trace-call":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCall = v.(bool)
+			}
+		case "-no-trace-call":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCall = !v.(bool)
+			}
+
+		case "-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = v.(bool)
+			}
+		case "-no-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = !v.(bool)
+			}
+
+		case "-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = v.(bool)
+			}
+		case "-no-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = !v.(bool)
+			}
+
+		case "-trace-dep":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceDep = append(input.Opt_TraceDep, v.([]string)[0])
+			}
+
+		case "-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = v.(bool)
+			}
+		case "-no-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = !v.(bool)
+			}
+
+		case "-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = v.(bool)
+			}
+		case "-no-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = !v.(bool)
+			}
+
+		case "-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = v.(bool)
+			}
+		case "-no-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = !v.(bool)
+			}
+
+		case "-trace-pkg":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TracePkg = append(input.Opt_TracePkg, v.([]string)[0])
+			}
+
+		case "-trace-stmt":
 			if !cut {
 				lit = "true"
 			}
@@ -702,9 +1395,338 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceVar = v.(bool)
+				input.Opt_TraceVar = v.(bool)
+			}
+		case "-no-trace-var":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceVar = !v.(bool)
+			}
+
+		case "-verbose", "-v":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Verbose = v.(bool)
+			}
+
+		default:
+			input.ErrorMessage = fmt.Sprintf("unknown option %q", optName)
+			return
+		}
+	}
+
+	expectedArgs := 1
+	func(...any) {}(expectedArgs)
+	if len(input.Arguments) <= 0 {
+		input.ErrorMessage = fmt.Sprintf("too few arguments: required %d, got %d", expectedArgs, len(input.Arguments))
+		return
+	}
+	if v, err := parseValue("string", input.Arguments[0:]...); err != nil {
+		input.ErrorMessage = fmt.Sprintf("value %q is not assignable to argument at [%d]", input.Arguments[0], 0)
+		return
+	} else {
+		input.Arg_Package = v.(string)
+	}
+}
+
+type Input_Run struct {
+	Opt_CopyOnly          []string
+	Opt_CopyOnlyNot       string
+	Opt_ExcludeFunc       []string
+	Opt_Format            string
+	Opt_GoBuildArg        []string
+	Opt_Goroutine         bool
+	Opt_Help              bool
+	Opt_IncludeFunc       []string
+	Opt_Indent            bool
+	Opt_Mod               string
+	Opt_Output            string
+	Opt_Overlay           bool
+	Opt_Seed              int64
+	Opt_Timestamp         bool
+	Opt_TraceCall         bool
+	Opt_TraceChan         bool
+	Opt_TraceCond         bool
+	Opt_TraceDep          []string
+	Opt_TraceErrorsOnly   bool
+	Opt_TraceExternCalls  bool
+	Opt_TraceLocalModules bool
+	Opt_TracePkg          []string
+	Opt_TraceStmt         bool
+	Opt_TraceVar          bool
+	Opt_Verbose           bool
+	Opt_Width             int64
+	Arg_Package           string
+	Arg_Arguments         []string
+	Subcommand            []string
+	Options               []string
+	Arguments             []string
+
+	ErrorMessage string
+}
+
+func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
+	*input = Input_Run{Opt_CopyOnly: []string{},
+		Opt_CopyOnlyNot:       ".*",
+		Opt_ExcludeFunc:       []string{},
+		Opt_Format:            "text",
+		Opt_GoBuildArg:        []string{},
+		Opt_Goroutine:         true,
+		Opt_Help:              false,
+		Opt_IncludeFunc:       []string{},
+		Opt_Indent:            false,
+		Opt_Mod:               "",
+		Opt_Output:            "",
+		Opt_Overlay:           false,
+		Opt_Seed:              0,
+		Opt_Timestamp:         true,
+		Opt_TraceCall:         true,
+		Opt_TraceChan:         false,
+		Opt_TraceCond:         false,
+		Opt_TraceDep:          []string{},
+		Opt_TraceErrorsOnly:   false,
+		Opt_TraceExternCalls:  false,
+		Opt_TraceLocalModules: false,
+		Opt_TracePkg:          []string{},
+		Opt_TraceStmt:         true,
+		Opt_TraceVar:          true,
+		Opt_Verbose:           false,
+		Opt_Width:             0,
+		Subcommand:            subcommand,
+		Options:               options,
+		Arguments:             arguments,
+	}
+
+	for _, arg := range input.Options {
+		optName, lit, cut := strings.Cut(arg, "=")
+		func(...any) {}(optName, lit, cut)
+
+		switch optName {
+		case "-copy-only":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_CopyOnly = append(input.Opt_CopyOnly, v.([]string)[0])
+			}
+
+		case "-copy-only-not":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_CopyOnlyNot = v.(string)
+			}
+
+		case "-exclude-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_ExcludeFunc = append(input.Opt_ExcludeFunc, v.([]string)[0])
+			}
+
+		case "-format":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Format = v.(string)
+			}
+
+		case "-go-build-arg", "-a":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_GoBuildArg = append(input.Opt_GoBuildArg, v.([]string)[0])
+			}
+
+		case "-goroutine":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Goroutine = v.(bool)
+			}
+		case "-no-goroutine":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Goroutine = !v.(bool)
+			}
+
+		case "-help", "-h":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Help = v.(bool)
+			}
+
+		case "-include-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_IncludeFunc = append(input.Opt_IncludeFunc, v.([]string)[0])
+			}
+
+		case "-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = v.(bool)
+			}
+		case "-no-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = !v.(bool)
+			}
+
+		case "-mod":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Mod = v.(string)
+			}
+
+		case "-output":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Output = v.(string)
+			}
+
+		case "-overlay":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Overlay = v.(bool)
+			}
+
+		case "-seed":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("int64", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Seed = v.(int64)
+			}
+
+		case "-timestamp":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Timestamp = v.(bool)
+			}
+		case "-no-timestamp":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Timestamp = !v.(bool)
+			}
+
+		case "-trace-call":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCall = v.(bool)
+			}
+		case "-no-trace-call":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCall = !v.(bool)
 			}
-		case "-no-trace-var":
+
+		case "-trace-chan":
 			if !cut {
 				lit = "true"
 			}
@@ -712,10 +1734,9 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceVar = !v.(bool)
+				input.Opt_TraceChan = v.(bool)
 			}
-
-		case "-verbose", "-v":
+		case "-no-trace-chan":
 			if !cut {
 				lit = "true"
 			}
@@ -723,99 +1744,31 @@ func (input *Input_Rewrite) resolveInput(subcommand, options, arguments []string
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Verbose = v.(bool)
+				input.Opt_TraceChan = !v.(bool)
 			}
 
-		default:
-			input.ErrorMessage = fmt.Sprintf("unknown option %q", optName)
-			return
-		}
-	}
-
-	expectedArgs := 1
-	func(...any) {}(expectedArgs)
-	if len(input.Arguments) <= 0 {
-		input.ErrorMessage = fmt.Sprintf("too few arguments: required %d, got %d", expectedArgs, len(input.Arguments))
-		return
-	}
-	if v, err := parseValue("string", input.Arguments[0:]...); err != nil {
-		input.ErrorMessage = fmt.Sprintf("value %q is not assignable to argument at [%d]", input.Arguments[0], 0)
-		return
-	} else {
-		input.Arg_Package = v.(string)
-	}
-}
-
-type Input_Run struct {
-	Opt_CopyOnly    []string
-	Opt_CopyOnlyNot string
-	Opt_GoBuildArg  []string
-	Opt_Goroutine   bool
-	Opt_Help        bool
-	Opt_Seed        int64
-	Opt_Timestamp   bool
-	Opt_TraceCall   bool
-	Opt_TraceStmt   bool
-	Opt_TraceVar    bool
-	Opt_Verbose     bool
-	Opt_Width       int64
-	Arg_Package     string
-	Arg_Arguments   []string
-	Subcommand      []string
-	Options         []string
-	Arguments       []string
-
-	ErrorMessage string
-}
-
-func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
-	*input = Input_Run{Opt_CopyOnly: []string{},
-		Opt_CopyOnlyNot: ".*",
-		Opt_GoBuildArg:  []string{},
-		Opt_Goroutine:   true,
-		Opt_Help:        false,
-		Opt_Seed:        0,
-		Opt_Timestamp:   true,
-		Opt_TraceCall:   true,
-		Opt_TraceStmt:   true,
-		Opt_TraceVar:    true,
-		Opt_Verbose:     false,
-		Opt_Width:       0,
-		Subcommand:      subcommand,
-		Options:         options,
-		Arguments:       arguments,
-	}
-
-	for _, arg := range input.Options {
-		optName, lit, cut := strings.Cut(arg, "=")
-		func(...any) {}(optName, lit, cut)
-
-		switch optName {
-		case "-copy-only":
+		case "-trace-cond":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("[]string", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_CopyOnly = append(input.Opt_CopyOnly, v.([]string)[0])
+				input.Opt_TraceCond = v.(bool)
 			}
-
-		case "-copy-only-not":
+		case "-no-trace-cond":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("string", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_CopyOnlyNot = v.(string)
+				input.Opt_TraceCond = !v.(bool)
 			}
 
-		case "-go-build-arg", "-a":
+		case "-trace-dep":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
@@ -824,10 +1777,10 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_GoBuildArg = append(input.Opt_GoBuildArg, v.([]string)[0])
+				input.Opt_TraceDep = append(input.Opt_TraceDep, v.([]string)[0])
 			}
 
-		case "-goroutine":
+		case "-trace-errors-only":
 			if !cut {
 				lit = "true"
 			}
@@ -835,9 +1788,9 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Goroutine = v.(bool)
+				input.Opt_TraceErrorsOnly = v.(bool)
 			}
-		case "-no-goroutine":
+		case "-no-trace-errors-only":
 			if !cut {
 				lit = "true"
 			}
@@ -845,10 +1798,10 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Goroutine = !v.(bool)
+				input.Opt_TraceErrorsOnly = !v.(bool)
 			}
 
-		case "-help", "-h":
+		case "-trace-extern-calls":
 			if !cut {
 				lit = "true"
 			}
@@ -856,22 +1809,20 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Help = v.(bool)
+				input.Opt_TraceExternCalls = v.(bool)
 			}
-
-		case "-seed":
+		case "-no-trace-extern-calls":
 			if !cut {
-				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
-				return
+				lit = "true"
 			}
-			if v, err := parseValue("int64", lit); err != nil {
+			if v, err := parseValue("bool", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Seed = v.(int64)
+				input.Opt_TraceExternCalls = !v.(bool)
 			}
 
-		case "-timestamp":
+		case "-trace-local-modules":
 			if !cut {
 				lit = "true"
 			}
@@ -879,9 +1830,9 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = v.(bool)
+				input.Opt_TraceLocalModules = v.(bool)
 			}
-		case "-no-timestamp":
+		case "-no-trace-local-modules":
 			if !cut {
 				lit = "true"
 			}
@@ -889,28 +1840,19 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_Timestamp = !v.(bool)
+				input.Opt_TraceLocalModules = !v.(bool)
 			}
 
-		case "-trace-call":
+		case "-trace-pkg":
 			if !cut {
-				lit = "true"
-			}
-			if v, err := parseValue("bool", lit); err != nil {
-				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
 				return
-			} else {
-				input.Opt_TraceCall = v.(bool)
-			}
-		case "-no-trace-call":
-			if !cut {
-				lit = "true"
 			}
-			if v, err := parseValue("bool", lit); err != nil {
+			if v, err := parseValue("[]string", lit); err != nil {
 				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
 				return
 			} else {
-				input.Opt_TraceCall = !v.(bool)
+				input.Opt_TracePkg = append(input.Opt_TracePkg, v.([]string)[0])
 			}
 
 		case "-trace-stmt":
@@ -1011,37 +1953,61 @@ func (input *Input_Run) resolveInput(subcommand, options, arguments []string) {
 }
 
 type Input_Version struct {
-	Opt_CopyOnly    []string
-	Opt_CopyOnlyNot string
-	Opt_Goroutine   bool
-	Opt_Help        bool
-	Opt_Seed        int64
-	Opt_Timestamp   bool
-	Opt_TraceCall   bool
-	Opt_TraceStmt   bool
-	Opt_TraceVar    bool
-	Opt_Verbose     bool
-	Subcommand      []string
-	Options         []string
-	Arguments       []string
+	Opt_CopyOnly          []string
+	Opt_CopyOnlyNot       string
+	Opt_ExcludeFunc       []string
+	Opt_Format            string
+	Opt_Goroutine         bool
+	Opt_Help              bool
+	Opt_IncludeFunc       []string
+	Opt_Indent            bool
+	Opt_Output            string
+	Opt_Seed              int64
+	Opt_Timestamp         bool
+	Opt_TraceCall         bool
+	Opt_TraceChan         bool
+	Opt_TraceCond         bool
+	Opt_TraceDep          []string
+	Opt_TraceErrorsOnly   bool
+	Opt_TraceExternCalls  bool
+	Opt_TraceLocalModules bool
+	Opt_TracePkg          []string
+	Opt_TraceStmt         bool
+	Opt_TraceVar          bool
+	Opt_Verbose           bool
+	Subcommand            []string
+	Options               []string
+	Arguments             []string
 
 	ErrorMessage string
 }
 
 func (input *Input_Version) resolveInput(subcommand, options, arguments []string) {
 	*input = Input_Version{Opt_CopyOnly: []string{},
-		Opt_CopyOnlyNot: ".*",
-		Opt_Goroutine:   true,
-		Opt_Help:        false,
-		Opt_Seed:        0,
-		Opt_Timestamp:   true,
-		Opt_TraceCall:   true,
-		Opt_TraceStmt:   true,
-		Opt_TraceVar:    true,
-		Opt_Verbose:     false,
-		Subcommand:      subcommand,
-		Options:         options,
-		Arguments:       arguments,
+		Opt_CopyOnlyNot:       ".*",
+		Opt_ExcludeFunc:       []string{},
+		Opt_Format:            "text",
+		Opt_Goroutine:         true,
+		Opt_Help:              false,
+		Opt_IncludeFunc:       []string{},
+		Opt_Indent:            false,
+		Opt_Output:            "",
+		Opt_Seed:              0,
+		Opt_Timestamp:         true,
+		Opt_TraceCall:         true,
+		Opt_TraceChan:         false,
+		Opt_TraceCond:         false,
+		Opt_TraceDep:          []string{},
+		Opt_TraceErrorsOnly:   false,
+		Opt_TraceExternCalls:  false,
+		Opt_TraceLocalModules: false,
+		Opt_TracePkg:          []string{},
+		Opt_TraceStmt:         true,
+		Opt_TraceVar:          true,
+		Opt_Verbose:           false,
+		Subcommand:            subcommand,
+		Options:               options,
+		Arguments:             arguments,
 	}
 
 	for _, arg := range input.Options {
@@ -1073,6 +2039,30 @@ func (input *Input_Version) resolveInput(subcommand, options, arguments []string
 				input.Opt_CopyOnlyNot = v.(string)
 			}
 
+		case "-exclude-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_ExcludeFunc = append(input.Opt_ExcludeFunc, v.([]string)[0])
+			}
+
+		case "-format":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Format = v.(string)
+			}
+
 		case "-goroutine":
 			if !cut {
 				lit = "true"
@@ -1105,6 +2095,51 @@ func (input *Input_Version) resolveInput(subcommand, options, arguments []string
 				input.Opt_Help = v.(bool)
 			}
 
+		case "-include-func":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_IncludeFunc = append(input.Opt_IncludeFunc, v.([]string)[0])
+			}
+
+		case "-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = v.(bool)
+			}
+		case "-no-indent":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Indent = !v.(bool)
+			}
+
+		case "-output":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_Output = v.(string)
+			}
+
 		case "-seed":
 			if !cut {
 				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
@@ -1159,6 +2194,135 @@ func (input *Input_Version) resolveInput(subcommand, options, arguments []string
 				input.Opt_TraceCall = !v.(bool)
 			}
 
+		case "-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = v.(bool)
+			}
+		case "-no-trace-chan":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceChan = !v.(bool)
+			}
+
+		case "-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = v.(bool)
+			}
+		case "-no-trace-cond":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceCond = !v.(bool)
+			}
+
+		case "-trace-dep":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceDep = append(input.Opt_TraceDep, v.([]string)[0])
+			}
+
+		case "-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = v.(bool)
+			}
+		case "-no-trace-errors-only":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceErrorsOnly = !v.(bool)
+			}
+
+		case "-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = v.(bool)
+			}
+		case "-no-trace-extern-calls":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceExternCalls = !v.(bool)
+			}
+
+		case "-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = v.(bool)
+			}
+		case "-no-trace-local-modules":
+			if !cut {
+				lit = "true"
+			}
+			if v, err := parseValue("bool", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TraceLocalModules = !v.(bool)
+			}
+
+		case "-trace-pkg":
+			if !cut {
+				input.ErrorMessage = fmt.Sprintf("value is not specified to option %q", optName)
+				return
+			}
+			if v, err := parseValue("[]string", lit); err != nil {
+				input.ErrorMessage = fmt.Sprintf("value %q is not assignable to option %q", lit, optName)
+				return
+			} else {
+				input.Opt_TracePkg = append(input.Opt_TracePkg, v.([]string)[0])
+			}
+
 		case "-trace-stmt":
 			if !cut {
 				lit = "true"
@@ -1320,19 +2484,19 @@ func GetProgram() string {
 func GetDoc(subcommands []string) string {
 	switch strings.Join(subcommands, " ") {
 	case "":
-		return "xtracego \n\n    Syntax:\n        $ xtracego  [<option>]...\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Subcommands:\n        build:\n            Rewrites the source files in the specified package and places these files in the build directory.\n            Executes go build at the specified directory with the given arguments.\n\n        rewrite:\n            Rewrites the source files in the specified package and places these files in the output directory.\n            The rewritten files includes Go code to log trace information.\n            If go.mod of the module of the package is found, it is copied to the output directory.\n\n        run:\n            Rewrites the source files in the specified package and places these files in a temporary directory.\n            Executes go build at the temporary directory with the given arguments.\n            Thereafter, the built executable file is executed at the current working directory.\n\n        version:\n            Prints the version of xtracego.\n\n\n"
+		return "xtracego \n\n    Syntax:\n        $ xtracego  [<option>]...\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -exclude-func=<string> ...:\n            Specifies functions not to be traced by regular expressions.\n            Functions matching one of these regular expressions are not traced even if they match -include-func.\n\n        -format=<string>(default=\"text\"):\n            Format of trace messages, which is one of the following:\n            - text: human-readable lines padded with dashes to the terminal width.\n            - jsonl: JSON Lines, where each line is a JSON object with kind, timestamp, goroutine, function, file, line, column, source, name, and value.\n            - chrome: Chrome Trace Event Format, where calling and returning functions are events on the threads of goroutine IDs, which can be opened by chrome://tracing or Perfetto.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -include-func=<string> ...:\n            Specifies functions to be traced by regular expressions.\n            A function is matched by its name such as pkg.Func, pkg.T.Method, or pkg.(*T).Method, where pkg is the package name.\n            Function literals are traced if their enclosing function is traced, and declarations at the package level are matched by pkg.init.\n            If specified, only functions matching one of these regular expressions are traced.\n\n        -indent[=<boolean>](default=false),\n        -no-indent[=<boolean>]:\n            Whether indent trace messages by the depth of function calls in each goroutine or not.\n\n        -output=<string>(default=\"\"):\n            Destination of trace messages, which is one of the following:\n            - file path: trace messages are written to the file, which is created or truncated when the program starts.\n            - fd:N: trace messages are written to the file descriptor N.\n            - unix:/path/to/socket: trace messages are written to the Unix domain socket.\n            If not specified, trace messages are written to the standard error.\n            The environment variable XTRACEGO_OUTPUT overrides this option when the program starts.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-chan[=<boolean>](default=false),\n        -no-trace-chan[=<boolean>]:\n            Whether trace sending to, receiving from, and closing channels or not.\n            A line of waiting on send or receive is printed before each operation so that blocking points are visible.\n            Communications of select statements are not traced.\n\n        -trace-cond[=<boolean>](default=false),\n        -no-trace-cond[=<boolean>]:\n            Whether trace the values of if conditions and switch tags, and the dynamic types of type switches or not.\n            Each of them is evaluated exactly once as in the original code.\n\n        -trace-dep=<string> ...:\n            Package patterns such as github.com/foo/bar/... to select packages of the dependencies to be traced, which are matched against import paths.\n            The modules providing the matched packages are copied from the module cache into the output directory, their matched packages are rewritten, and the modules are replaced with the copies by replace directives added to go.mod, or go.work in workspace mode.\n            With -overlay, go.mod or go.work is overlaid with the copy having the replace directives, because the files in the module cache cannot be overlaid.\n            The vendored packages are rewritten in the vendor directory instead.\n\n        -trace-errors-only[=<boolean>](default=false),\n        -no-trace-errors-only[=<boolean>]:\n            Whether trace only non-nil errors returned from functions instead of calls and return values or not.\n            Each non-nil result of an error type is printed with its message, so that where an error came from is traced.\n\n        -trace-extern-calls[=<boolean>](default=false),\n        -no-trace-extern-calls[=<boolean>]:\n            Whether trace the arguments and the results of calls of functions in packages outside the module, such as os.ReadFile(path), or not.\n            Only calls of package-level functions qualified by imported package names are traced, and the arguments are evaluated exactly once as in the original code.\n\n        -trace-local-modules[=<boolean>](default=false),\n        -no-trace-local-modules[=<boolean>]:\n            Whether trace the packages in the local modules, which are the modules used in the go.work workspace and the modules replaced with local directories, or not.\n            The local modules are copied or overlaid regardless of this option, and their source files are rewritten only if this option is enabled.\n\n        -trace-pkg=<string> ...:\n            Package patterns such as ./internal/... and example.com/lib/... to select packages to be traced, which are matched against import paths.\n            Relative patterns are resolved from the current directory into import paths in the main module, and \"...\" matches any string as patterns of the go command.\n            The source files of the other packages are copied without being rewritten.\n            If not specified, all packages in the main module are traced.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Subcommands:\n        build:\n            Rewrites the source files in the specified package and places these files in the build directory.\n            Executes go build at the specified directory with the given arguments.\n\n        rewrite:\n            Rewrites the source files in the specified package and places these files in the output directory.\n            The rewritten files includes Go code to log trace information.\n            If go.mod of the module of the package is found, it is copied to the output directory with go.sum and the vendor directory.\n\n        run:\n            Rewrites the source files in the specified package and places these files in a temporary directory.\n            Executes go build at the temporary directory with the given arguments.\n            Thereafter, the built executable file is executed at the current working directory.\n\n        version:\n            Prints the version of xtracego.\n\n\n"
 
 	case "build":
-		return "xtracego build\n\n    Description:\n        Rewrites the source files in the specified package and places these files in the build directory.\n        Executes go build at the specified directory with the given arguments.\n\n    Syntax:\n        $ xtracego build [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -build-directory=<string>, -o=<string>(default=\"\"):\n            The source files included the specified package are rewritten and placed in this directory which is used as a current working directory to execute go build.\n            This option is required.\n\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -go-build-arg=<string> ..., -a=<string> ...:\n            Arguments to be passed to the go build command.\n            If there are multiple arguments for go build, this option can be specified multiple times.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten and built.\n            The way to specify the package is as same as xtracego rewrite command.\n\n\n"
+		return "xtracego build\n\n    Description:\n        Rewrites the source files in the specified package and places these files in the build directory.\n        Executes go build at the specified directory with the given arguments.\n\n    Syntax:\n        $ xtracego build [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -build-directory=<string>, -o=<string>(default=\"\"):\n            The source files included the specified package are rewritten and placed in this directory which is used as a current working directory to execute go build.\n            This option is required.\n\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -exclude-func=<string> ...:\n            Specifies functions not to be traced by regular expressions.\n            Functions matching one of these regular expressions are not traced even if they match -include-func.\n\n        -format=<string>(default=\"text\"):\n            Format of trace messages, which is one of the following:\n            - text: human-readable lines padded with dashes to the terminal width.\n            - jsonl: JSON Lines, where each line is a JSON object with kind, timestamp, goroutine, function, file, line, column, source, name, and value.\n            - chrome: Chrome Trace Event Format, where calling and returning functions are events on the threads of goroutine IDs, which can be opened by chrome://tracing or Perfetto.\n\n        -go-build-arg=<string> ..., -a=<string> ...:\n            Arguments to be passed to the go build command.\n            If there are multiple arguments for go build, this option can be specified multiple times.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -include-func=<string> ...:\n            Specifies functions to be traced by regular expressions.\n            A function is matched by its name such as pkg.Func, pkg.T.Method, or pkg.(*T).Method, where pkg is the package name.\n            Function literals are traced if their enclosing function is traced, and declarations at the package level are matched by pkg.init.\n            If specified, only functions matching one of these regular expressions are traced.\n\n        -indent[=<boolean>](default=false),\n        -no-indent[=<boolean>]:\n            Whether indent trace messages by the depth of function calls in each goroutine or not.\n\n        -mod=<string>(default=\"\"):\n            Module download mode passed to go build as -mod, which is one of mod, readonly, and vendor.\n            If not specified, go build follows the settings of the module such as GOFLAGS and the vendor directory.\n            go.mod, go.sum, and the vendor directory of the module are used as they are, and go mod tidy is not executed.\n\n        -output=<string>(default=\"\"):\n            Destination of trace messages, which is one of the following:\n            - file path: trace messages are written to the file, which is created or truncated when the program starts.\n            - fd:N: trace messages are written to the file descriptor N.\n            - unix:/path/to/socket: trace messages are written to the Unix domain socket.\n            If not specified, trace messages are written to the standard error.\n            The environment variable XTRACEGO_OUTPUT overrides this option when the program starts.\n\n        -overlay[=<boolean>](default=false):\n            Whether build the package in place with go build -overlay or not.\n            Only the rewritten source files, the generated library, and overlay.json are placed in the build directory, and the built executable file is placed in the build directory.\n            The module, its vendor directory, and go.sum are used as they are.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-chan[=<boolean>](default=false),\n        -no-trace-chan[=<boolean>]:\n            Whether trace sending to, receiving from, and closing channels or not.\n            A line of waiting on send or receive is printed before each operation so that blocking points are visible.\n            Communications of select statements are not traced.\n\n        -trace-cond[=<boolean>](default=false),\n        -no-trace-cond[=<boolean>]:\n            Whether trace the values of if conditions and switch tags, and the dynamic types of type switches or not.\n            Each of them is evaluated exactly once as in the original code.\n\n        -trace-dep=<string> ...:\n            Package patterns such as github.com/foo/bar/... to select packages of the dependencies to be traced, which are matched against import paths.\n            The modules providing the matched packages are copied from the module cache into the output directory, their matched packages are rewritten, and the modules are replaced with the copies by replace directives added to go.mod, or go.work in workspace mode.\n            With -overlay, go.mod or go.work is overlaid with the copy having the replace directives, because the files in the module cache cannot be overlaid.\n            The vendored packages are rewritten in the vendor directory instead.\n\n        -trace-errors-only[=<boolean>](default=false),\n        -no-trace-errors-only[=<boolean>]:\n            Whether trace only non-nil errors returned from functions instead of calls and return values or not.\n            Each non-nil result of an error type is printed with its message, so that where an error came from is traced.\n\n        -trace-extern-calls[=<boolean>](default=false),\n        -no-trace-extern-calls[=<boolean>]:\n            Whether trace the arguments and the results of calls of functions in packages outside the module, such as os.ReadFile(path), or not.\n            Only calls of package-level functions qualified by imported package names are traced, and the arguments are evaluated exactly once as in the original code.\n\n        -trace-local-modules[=<boolean>](default=false),\n        -no-trace-local-modules[=<boolean>]:\n            Whether trace the packages in the local modules, which are the modules used in the go.work workspace and the modules replaced with local directories, or not.\n            The local modules are copied or overlaid regardless of this option, and their source files are rewritten only if this option is enabled.\n\n        -trace-pkg=<string> ...:\n            Package patterns such as ./internal/... and example.com/lib/... to select packages to be traced, which are matched against import paths.\n            Relative patterns are resolved from the current directory into import paths in the main module, and \"...\" matches any string as patterns of the go command.\n            The source files of the other packages are copied without being rewritten.\n            If not specified, all packages in the main module are traced.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten and built.\n            The way to specify the package is as same as xtracego rewrite command.\n\n\n"
 
 	case "rewrite":
-		return "xtracego rewrite\n\n    Description:\n        Rewrites the source files in the specified package and places these files in the output directory.\n        The rewritten files includes Go code to log trace information.\n        If go.mod of the module of the package is found, it is copied to the output directory.\n\n    Syntax:\n        $ xtracego rewrite [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -output-directory=<string>, -o=<string>(default=\"\"):\n            Output directory to place the rewritten source files of the package.\n            This option is required.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten can be specified by path to a local directory or paths to local source files of a main package.\n            \n            When the package is specified with a local directory path, go.mod must be found at the ancestors of the current working directory.\n            Dependencies in the same module and external dependencies are resolved via the go.mod.\n            \n            When the package is specified with local source file paths, the source files must have extension .go, be in the same directory, be in the main package, and contain only one main function.\n            If go.mod is found at the ancestors of the current working directory, dependencies in the same module and external dependencies are resolved via the go.mod.\n\n\n"
+		return "xtracego rewrite\n\n    Description:\n        Rewrites the source files in the specified package and places these files in the output directory.\n        The rewritten files includes Go code to log trace information.\n        If go.mod of the module of the package is found, it is copied to the output directory with go.sum and the vendor directory.\n\n    Syntax:\n        $ xtracego rewrite [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -exclude-func=<string> ...:\n            Specifies functions not to be traced by regular expressions.\n            Functions matching one of these regular expressions are not traced even if they match -include-func.\n\n        -format=<string>(default=\"text\"):\n            Format of trace messages, which is one of the following:\n            - text: human-readable lines padded with dashes to the terminal width.\n            - jsonl: JSON Lines, where each line is a JSON object with kind, timestamp, goroutine, function, file, line, column, source, name, and value.\n            - chrome: Chrome Trace Event Format, where calling and returning functions are events on the threads of goroutine IDs, which can be opened by chrome://tracing or Perfetto.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -include-func=<string> ...:\n            Specifies functions to be traced by regular expressions.\n            A function is matched by its name such as pkg.Func, pkg.T.Method, or pkg.(*T).Method, where pkg is the package name.\n            Function literals are traced if their enclosing function is traced, and declarations at the package level are matched by pkg.init.\n            If specified, only functions matching one of these regular expressions are traced.\n\n        -indent[=<boolean>](default=false),\n        -no-indent[=<boolean>]:\n            Whether indent trace messages by the depth of function calls in each goroutine or not.\n\n        -output=<string>(default=\"\"):\n            Destination of trace messages, which is one of the following:\n            - file path: trace messages are written to the file, which is created or truncated when the program starts.\n            - fd:N: trace messages are written to the file descriptor N.\n            - unix:/path/to/socket: trace messages are written to the Unix domain socket.\n            If not specified, trace messages are written to the standard error.\n            The environment variable XTRACEGO_OUTPUT overrides this option when the program starts.\n\n        -output-directory=<string>, -o=<string>(default=\"\"):\n            Output directory to place the rewritten source files of the package.\n            This option is required.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-chan[=<boolean>](default=false),\n        -no-trace-chan[=<boolean>]:\n            Whether trace sending to, receiving from, and closing channels or not.\n            A line of waiting on send or receive is printed before each operation so that blocking points are visible.\n            Communications of select statements are not traced.\n\n        -trace-cond[=<boolean>](default=false),\n        -no-trace-cond[=<boolean>]:\n            Whether trace the values of if conditions and switch tags, and the dynamic types of type switches or not.\n            Each of them is evaluated exactly once as in the original code.\n\n        -trace-dep=<string> ...:\n            Package patterns such as github.com/foo/bar/... to select packages of the dependencies to be traced, which are matched against import paths.\n            The modules providing the matched packages are copied from the module cache into the output directory, their matched packages are rewritten, and the modules are replaced with the copies by replace directives added to go.mod, or go.work in workspace mode.\n            With -overlay, go.mod or go.work is overlaid with the copy having the replace directives, because the files in the module cache cannot be overlaid.\n            The vendored packages are rewritten in the vendor directory instead.\n\n        -trace-errors-only[=<boolean>](default=false),\n        -no-trace-errors-only[=<boolean>]:\n            Whether trace only non-nil errors returned from functions instead of calls and return values or not.\n            Each non-nil result of an error type is printed with its message, so that where an error came from is traced.\n\n        -trace-extern-calls[=<boolean>](default=false),\n        -no-trace-extern-calls[=<boolean>]:\n            Whether trace the arguments and the results of calls of functions in packages outside the module, such as os.ReadFile(path), or not.\n            Only calls of package-level functions qualified by imported package names are traced, and the arguments are evaluated exactly once as in the original code.\n\n        -trace-local-modules[=<boolean>](default=false),\n        -no-trace-local-modules[=<boolean>]:\n            Whether trace the packages in the local modules, which are the modules used in the go.work workspace and the modules replaced with local directories, or not.\n            The local modules are copied or overlaid regardless of this option, and their source files are rewritten only if this option is enabled.\n\n        -trace-pkg=<string> ...:\n            Package patterns such as ./internal/... and example.com/lib/... to select packages to be traced, which are matched against import paths.\n            Relative patterns are resolved from the current directory into import paths in the main module, and \"...\" matches any string as patterns of the go command.\n            The source files of the other packages are copied without being rewritten.\n            If not specified, all packages in the main module are traced.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten can be specified by path to a local directory or paths to local source files of a main package.\n            \n            When the package is specified with a local directory path, go.mod must be found at the ancestors of the current working directory.\n            Dependencies in the same module and external dependencies are resolved via the go.mod.\n            \n            When the package is specified with local source file paths, the source files must have extension .go, be in the same directory, be in the main package, and contain only one main function.\n            If go.mod is found at the ancestors of the current working directory, dependencies in the same module and external dependencies are resolved via the go.mod.\n\n\n"
 
 	case "run":
-		return "xtracego run\n\n    Description:\n        Rewrites the source files in the specified package and places these files in a temporary directory.\n        Executes go build at the temporary directory with the given arguments.\n        Thereafter, the built executable file is executed at the current working directory.\n\n    Syntax:\n        $ xtracego run [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -go-build-arg=<string> ..., -a=<string> ...:\n            Arguments to be passed to the go run command.\n            If there are multiple arguments for go build, this option can be specified multiple times.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n        -width=<integer>, -w=<integer>(default=0):\n            Terminal width to be used for formatting trace messages.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten and built.\n            The way to specify the package is as same as xtracego rewrite command.\n\n        2. [<arguments:string>]...\n            Arguments to be passed to the main function.\n\n\n"
+		return "xtracego run\n\n    Description:\n        Rewrites the source files in the specified package and places these files in a temporary directory.\n        Executes go build at the temporary directory with the given arguments.\n        Thereafter, the built executable file is executed at the current working directory.\n\n    Syntax:\n        $ xtracego run [<option>|<argument>]... [-- [<argument>]...]\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -exclude-func=<string> ...:\n            Specifies functions not to be traced by regular expressions.\n            Functions matching one of these regular expressions are not traced even if they match -include-func.\n\n        -format=<string>(default=\"text\"):\n            Format of trace messages, which is one of the following:\n            - text: human-readable lines padded with dashes to the terminal width.\n            - jsonl: JSON Lines, where each line is a JSON object with kind, timestamp, goroutine, function, file, line, column, source, name, and value.\n            - chrome: Chrome Trace Event Format, where calling and returning functions are events on the threads of goroutine IDs, which can be opened by chrome://tracing or Perfetto.\n\n        -go-build-arg=<string> ..., -a=<string> ...:\n            Arguments to be passed to the go run command.\n            If there are multiple arguments for go build, this option can be specified multiple times.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -include-func=<string> ...:\n            Specifies functions to be traced by regular expressions.\n            A function is matched by its name such as pkg.Func, pkg.T.Method, or pkg.(*T).Method, where pkg is the package name.\n            Function literals are traced if their enclosing function is traced, and declarations at the package level are matched by pkg.init.\n            If specified, only functions matching one of these regular expressions are traced.\n\n        -indent[=<boolean>](default=false),\n        -no-indent[=<boolean>]:\n            Whether indent trace messages by the depth of function calls in each goroutine or not.\n\n        -mod=<string>(default=\"\"):\n            Module download mode passed to go build as -mod, which is one of mod, readonly, and vendor.\n            If not specified, go build follows the settings of the module such as GOFLAGS and the vendor directory.\n            go.mod, go.sum, and the vendor directory of the module are used as they are, and go mod tidy is not executed.\n\n        -output=<string>(default=\"\"):\n            Destination of trace messages, which is one of the following:\n            - file path: trace messages are written to the file, which is created or truncated when the program starts.\n            - fd:N: trace messages are written to the file descriptor N.\n            - unix:/path/to/socket: trace messages are written to the Unix domain socket.\n            If not specified, trace messages are written to the standard error.\n            The environment variable XTRACEGO_OUTPUT overrides this option when the program starts.\n\n        -overlay[=<boolean>](default=false):\n            Whether build the package in place with go build -overlay or not.\n            Only the rewritten source files, the generated library, and overlay.json are placed in the temporary directory.\n            The module, its vendor directory, and go.sum are used as they are.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-chan[=<boolean>](default=false),\n        -no-trace-chan[=<boolean>]:\n            Whether trace sending to, receiving from, and closing channels or not.\n            A line of waiting on send or receive is printed before each operation so that blocking points are visible.\n            Communications of select statements are not traced.\n\n        -trace-cond[=<boolean>](default=false),\n        -no-trace-cond[=<boolean>]:\n            Whether trace the values of if conditions and switch tags, and the dynamic types of type switches or not.\n            Each of them is evaluated exactly once as in the original code.\n\n        -trace-dep=<string> ...:\n            Package patterns such as github.com/foo/bar/... to select packages of the dependencies to be traced, which are matched against import paths.\n            The modules providing the matched packages are copied from the module cache into the output directory, their matched packages are rewritten, and the modules are replaced with the copies by replace directives added to go.mod, or go.work in workspace mode.\n            With -overlay, go.mod or go.work is overlaid with the copy having the replace directives, because the files in the module cache cannot be overlaid.\n            The vendored packages are rewritten in the vendor directory instead.\n\n        -trace-errors-only[=<boolean>](default=false),\n        -no-trace-errors-only[=<boolean>]:\n            Whether trace only non-nil errors returned from functions instead of calls and return values or not.\n            Each non-nil result of an error type is printed with its message, so that where an error came from is traced.\n\n        -trace-extern-calls[=<boolean>](default=false),\n        -no-trace-extern-calls[=<boolean>]:\n            Whether trace the arguments and the results of calls of functions in packages outside the module, such as os.ReadFile(path), or not.\n            Only calls of package-level functions qualified by imported package names are traced, and the arguments are evaluated exactly once as in the original code.\n\n        -trace-local-modules[=<boolean>](default=false),\n        -no-trace-local-modules[=<boolean>]:\n            Whether trace the packages in the local modules, which are the modules used in the go.work workspace and the modules replaced with local directories, or not.\n            The local modules are copied or overlaid regardless of this option, and their source files are rewritten only if this option is enabled.\n\n        -trace-pkg=<string> ...:\n            Package patterns such as ./internal/... and example.com/lib/... to select packages to be traced, which are matched against import paths.\n            Relative patterns are resolved from the current directory into import paths in the main module, and \"...\" matches any string as patterns of the go command.\n            The source files of the other packages are copied without being rewritten.\n            If not specified, all packages in the main module are traced.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n        -width=<integer>, -w=<integer>(default=0):\n            Terminal width to be used for formatting trace messages.\n\n    Arguments:\n        1.  <package:string>\n            Package to be rewritten and built.\n            The way to specify the package is as same as xtracego rewrite command.\n\n        2. [<arguments:string>]...\n            Arguments to be passed to the main function.\n\n\n"
 
 	case "version":
-		return "xtracego version\n\n    Description:\n        Prints the version of xtracego.\n\n    Syntax:\n        $ xtracego version [<option>]...\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n\n"
+		return "xtracego version\n\n    Description:\n        Prints the version of xtracego.\n\n    Syntax:\n        $ xtracego version [<option>]...\n\n    Options:\n        -copy-only=<string> ...:\n            Specifies source files not to be rewritten but only copied by regular expressions.\n            If a source file is included in the package and its absolute path matches this regular expression, it is only copied to the output directory.\n\n        -copy-only-not=<string>(default=\".*\"):\n            Same as -copy-only but source files whose absolute path  **DO NOT MATCH**  this regular expression are only copied.\n\n        -exclude-func=<string> ...:\n            Specifies functions not to be traced by regular expressions.\n            Functions matching one of these regular expressions are not traced even if they match -include-func.\n\n        -format=<string>(default=\"text\"):\n            Format of trace messages, which is one of the following:\n            - text: human-readable lines padded with dashes to the terminal width.\n            - jsonl: JSON Lines, where each line is a JSON object with kind, timestamp, goroutine, function, file, line, column, source, name, and value.\n            - chrome: Chrome Trace Event Format, where calling and returning functions are events on the threads of goroutine IDs, which can be opened by chrome://tracing or Perfetto.\n\n        -goroutine[=<boolean>](default=true),\n        -no-goroutine[=<boolean>]:\n            Whether show goroutine ID or not.\n\n        -help[=<boolean>], -h[=<boolean>](default=false):\n            Prints help message.\n\n        -include-func=<string> ...:\n            Specifies functions to be traced by regular expressions.\n            A function is matched by its name such as pkg.Func, pkg.T.Method, or pkg.(*T).Method, where pkg is the package name.\n            Function literals are traced if their enclosing function is traced, and declarations at the package level are matched by pkg.init.\n            If specified, only functions matching one of these regular expressions are traced.\n\n        -indent[=<boolean>](default=false),\n        -no-indent[=<boolean>]:\n            Whether indent trace messages by the depth of function calls in each goroutine or not.\n\n        -output=<string>(default=\"\"):\n            Destination of trace messages, which is one of the following:\n            - file path: trace messages are written to the file, which is created or truncated when the program starts.\n            - fd:N: trace messages are written to the file descriptor N.\n            - unix:/path/to/socket: trace messages are written to the Unix domain socket.\n            If not specified, trace messages are written to the standard error.\n            The environment variable XTRACEGO_OUTPUT overrides this option when the program starts.\n\n        -seed=<integer>(default=0):\n            Random seed for reproducibility of rewritten source files.\n            If not specified, the seed is generated randomly.\n\n        -timestamp[=<boolean>](default=true),\n        -no-timestamp[=<boolean>]:\n            Whether show timestamp or not.\n\n        -trace-call[=<boolean>](default=true),\n        -no-trace-call[=<boolean>]:\n            Whether trace calling and returning functions and methods or not.\n\n        -trace-chan[=<boolean>](default=false),\n        -no-trace-chan[=<boolean>]:\n            Whether trace sending to, receiving from, and closing channels or not.\n            A line of waiting on send or receive is printed before each operation so that blocking points are visible.\n            Communications of select statements are not traced.\n\n        -trace-cond[=<boolean>](default=false),\n        -no-trace-cond[=<boolean>]:\n            Whether trace the values of if conditions and switch tags, and the dynamic types of type switches or not.\n            Each of them is evaluated exactly once as in the original code.\n\n        -trace-dep=<string> ...:\n            Package patterns such as github.com/foo/bar/... to select packages of the dependencies to be traced, which are matched against import paths.\n            The modules providing the matched packages are copied from the module cache into the output directory, their matched packages are rewritten, and the modules are replaced with the copies by replace directives added to go.mod, or go.work in workspace mode.\n            With -overlay, go.mod or go.work is overlaid with the copy having the replace directives, because the files in the module cache cannot be overlaid.\n            The vendored packages are rewritten in the vendor directory instead.\n\n        -trace-errors-only[=<boolean>](default=false),\n        -no-trace-errors-only[=<boolean>]:\n            Whether trace only non-nil errors returned from functions instead of calls and return values or not.\n            Each non-nil result of an error type is printed with its message, so that where an error came from is traced.\n\n        -trace-extern-calls[=<boolean>](default=false),\n        -no-trace-extern-calls[=<boolean>]:\n            Whether trace the arguments and the results of calls of functions in packages outside the module, such as os.ReadFile(path), or not.\n            Only calls of package-level functions qualified by imported package names are traced, and the arguments are evaluated exactly once as in the original code.\n\n        -trace-local-modules[=<boolean>](default=false),\n        -no-trace-local-modules[=<boolean>]:\n            Whether trace the packages in the local modules, which are the modules used in the go.work workspace and the modules replaced with local directories, or not.\n            The local modules are copied or overlaid regardless of this option, and their source files are rewritten only if this option is enabled.\n\n        -trace-pkg=<string> ...:\n            Package patterns such as ./internal/... and example.com/lib/... to select packages to be traced, which are matched against import paths.\n            Relative patterns are resolved from the current directory into import paths in the main module, and \"...\" matches any string as patterns of the go command.\n            The source files of the other packages are copied without being rewritten.\n            If not specified, all packages in the main module are traced.\n\n        -trace-stmt[=<boolean>](default=true),\n        -no-trace-stmt[=<boolean>]:\n            Whether trace basic statements or not.\n\n        -trace-var[=<boolean>](default=true),\n        -no-trace-var[=<boolean>]:\n            Whether trace variables and constants or not.\n\n        -verbose[=<boolean>], -v[=<boolean>](default=false):\n            Whether to output verbose messages or not.\n\n\n"
 	default:
 		panic(fmt.Sprintf(`invalid subcommands: %v`, subcommands))
 	}
@@ -3,8 +3,10 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"log"
 	"math/rand"
 	"os"
@@ -12,6 +14,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Jumpaku/xtracego/internal"
@@ -84,23 +87,36 @@ func (h *cliHandler) Run_Rewrite(input Input_Rewrite) (err error) {
 	pkg := h.resolvePackage(input.Arg_Package)
 
 	cfg := internal.Config{
-		TraceStmt:     input.Opt_TraceStmt,
-		TraceVar:      input.Opt_TraceVar,
-		TraceCall:     input.Opt_TraceCall,
-		ShowTimestamp: input.Opt_Timestamp,
-		ShowGoroutine: input.Opt_Goroutine,
-		ResolveType:   pkg.ResolveType,
-		ModuleName:    pkg.Module,
-		UniqueString:  generateUniqueString(input.Opt_Seed),
-		LineWidth:     getTermWidth(0, false),
+		TraceStmt:        input.Opt_TraceStmt,
+		TraceVar:         input.Opt_TraceVar,
+		TraceCall:        input.Opt_TraceCall,
+		TraceCond:        input.Opt_TraceCond,
+		TraceChan:        input.Opt_TraceChan,
+		TraceExternCalls: input.Opt_TraceExternCalls,
+		TraceErrorsOnly:  input.Opt_TraceErrorsOnly,
+		IncludeFunc:      compileRegexps(input.Opt_IncludeFunc),
+		ExcludeFunc:      compileRegexps(input.Opt_ExcludeFunc),
+		ShowTimestamp:    input.Opt_Timestamp,
+		ShowGoroutine:    input.Opt_Goroutine,
+		Indent:           input.Opt_Indent,
+		ResolveType:      pkg.ResolveType,
+		ModuleName:       pkg.Module,
+		UniqueString:     generateUniqueString(input.Opt_Seed),
+		LineWidth:        getTermWidth(0, false),
+		TraceFormat:      getTraceFormat(input.Opt_Format),
+		TraceOutput:      input.Opt_Output,
 	}
 
-	h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot)
+	tracedDependencies := h.getTracedDependencies(pkg, input.Opt_TraceDep)
 
-	h.saveLibraryFiles(cfg, outDir)
+	h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot, input.Opt_TraceLocalModules, input.Opt_TracePkg, tracedDependencies, false)
+
+	h.saveLibraryFiles(cfg, h.getModuleOutDir(pkg, outDir))
 
 	if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
 		h.saveGoModFile(cfg, outDir)
+	} else {
+		h.replaceDependencies(cfg, tracedDependencies, outDir, h.getCopiedGoModFile(pkg, outDir), true)
 	}
 
 	return nil
@@ -118,33 +134,56 @@ func (h cliHandler) Run_Build(input Input_Build) (err error) {
 	h.verbose = input.Opt_Verbose
 
 	outDir := requireOption(input.Subcommand, "-build-directory", input.Opt_BuildDirectory)
+	mod := getMod(input.Opt_Mod)
 
 	pkg := h.resolvePackage(input.Arg_Package)
 
 	cfg := internal.Config{
-		TraceStmt:     input.Opt_TraceStmt,
-		TraceVar:      input.Opt_TraceVar,
-		TraceCall:     input.Opt_TraceCall,
-		ShowTimestamp: input.Opt_Timestamp,
-		ShowGoroutine: input.Opt_Goroutine,
-		ResolveType:   pkg.ResolveType,
-		ModuleName:    pkg.Module,
-		UniqueString:  generateUniqueString(input.Opt_Seed),
-		LineWidth:     getTermWidth(0, false),
+		TraceStmt:        input.Opt_TraceStmt,
+		TraceVar:         input.Opt_TraceVar,
+		TraceCall:        input.Opt_TraceCall,
+		TraceCond:        input.Opt_TraceCond,
+		TraceChan:        input.Opt_TraceChan,
+		TraceExternCalls: input.Opt_TraceExternCalls,
+		TraceErrorsOnly:  input.Opt_TraceErrorsOnly,
+		IncludeFunc:      compileRegexps(input.Opt_IncludeFunc),
+		ExcludeFunc:      compileRegexps(input.Opt_ExcludeFunc),
+		ShowTimestamp:    input.Opt_Timestamp,
+		ShowGoroutine:    input.Opt_Goroutine,
+		Indent:           input.Opt_Indent,
+		ResolveType:      pkg.ResolveType,
+		ModuleName:       pkg.Module,
+		UniqueString:     generateUniqueString(input.Opt_Seed),
+		LineWidth:        getTermWidth(0, false),
+		TraceFormat:      getTraceFormat(input.Opt_Format),
+		TraceOutput:      input.Opt_Output,
+	}
+
+	tracedDependencies := h.getTracedDependencies(pkg, input.Opt_TraceDep)
+
+	if input.Opt_Overlay {
+		overlayFile, buildTargets, buildDir := h.prepareOverlayBuild(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot, input.Opt_TraceLocalModules, input.Opt_TracePkg, tracedDependencies)
+
+		absOutDir, err := filepath.Abs(outDir)
+		panicIfError(err, "failed to get absolute path")
+		buildArgs := append([]string{"-overlay", overlayFile, "-o", absOutDir + string(filepath.Separator)}, getGoBuildArgs(mod, input.Opt_GoBuildArg)...)
+		h.execGoBuild(buildArgs, buildTargets, buildDir)
+
+		return nil
 	}
 
-	h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot)
+	h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot, input.Opt_TraceLocalModules, input.Opt_TracePkg, tracedDependencies, false)
 
-	h.saveLibraryFiles(cfg, outDir)
+	h.saveLibraryFiles(cfg, h.getModuleOutDir(pkg, outDir))
 
-	packageDir := h.getBuildPackageDir(pkg, outDir)
+	buildTargets := h.getBuildTargets(cfg, pkg, outDir)
 	if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
 		h.saveGoModFile(cfg, outDir)
+	} else {
+		h.replaceDependencies(cfg, tracedDependencies, outDir, h.getCopiedGoModFile(pkg, outDir), true)
 	}
 
-	h.execGoModTidy(outDir)
-
-	h.execGoBuild(input.Opt_GoBuildArg, packageDir, outDir)
+	h.execGoBuild(getGoBuildArgs(mod, input.Opt_GoBuildArg), buildTargets, h.getModuleOutDir(pkg, outDir))
 
 	return nil
 }
@@ -160,6 +199,8 @@ func (h cliHandler) Run_Run(input Input_Run) error {
 
 	h.verbose = input.Opt_Verbose
 
+	mod := getMod(input.Opt_Mod)
+
 	outDir, err := os.MkdirTemp("", "xtracego_*")
 	panicIfError(err, "failed to create temp dir")
 	defer os.RemoveAll(outDir)
@@ -167,32 +208,50 @@ func (h cliHandler) Run_Run(input Input_Run) error {
 	pkg := h.resolvePackage(input.Arg_Package)
 
 	cfg := internal.Config{
-		TraceStmt:     input.Opt_TraceStmt,
-		TraceVar:      input.Opt_TraceVar,
-		TraceCall:     input.Opt_TraceCall,
-		ShowTimestamp: input.Opt_Timestamp,
-		ShowGoroutine: input.Opt_Goroutine,
-		ResolveType:   pkg.ResolveType,
-		ModuleName:    pkg.Module,
-		UniqueString:  generateUniqueString(input.Opt_Seed),
-		LineWidth:     getTermWidth(int(input.Opt_Width), true),
+		TraceStmt:        input.Opt_TraceStmt,
+		TraceVar:         input.Opt_TraceVar,
+		TraceCall:        input.Opt_TraceCall,
+		TraceCond:        input.Opt_TraceCond,
+		TraceChan:        input.Opt_TraceChan,
+		TraceExternCalls: input.Opt_TraceExternCalls,
+		TraceErrorsOnly:  input.Opt_TraceErrorsOnly,
+		IncludeFunc:      compileRegexps(input.Opt_IncludeFunc),
+		ExcludeFunc:      compileRegexps(input.Opt_ExcludeFunc),
+		ShowTimestamp:    input.Opt_Timestamp,
+		ShowGoroutine:    input.Opt_Goroutine,
+		Indent:           input.Opt_Indent,
+		ResolveType:      pkg.ResolveType,
+		ModuleName:       pkg.Module,
+		UniqueString:     generateUniqueString(input.Opt_Seed),
+		LineWidth:        getTermWidth(int(input.Opt_Width), true),
+		TraceFormat:      getTraceFormat(input.Opt_Format),
+		TraceOutput:      input.Opt_Output,
 	}
 
-	h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot)
+	execFile, err := filepath.Abs(filepath.Join(outDir, cfg.ExecutableFileName()))
+	panicIfError(err, "failed to get absolute path")
 
-	h.saveLibraryFiles(cfg, outDir)
+	tracedDependencies := h.getTracedDependencies(pkg, input.Opt_TraceDep)
 
-	packageDir := h.getBuildPackageDir(pkg, outDir)
-	if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
-		h.saveGoModFile(cfg, outDir)
-	}
+	if input.Opt_Overlay {
+		overlayFile, buildTargets, buildDir := h.prepareOverlayBuild(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot, input.Opt_TraceLocalModules, input.Opt_TracePkg, tracedDependencies)
 
-	h.execGoModTidy(outDir)
+		buildArgs := append([]string{"-overlay", overlayFile}, getGoBuildArgs(mod, input.Opt_GoBuildArg)...)
+		h.execGoBuild(append(buildArgs, "-o", execFile), buildTargets, buildDir)
+	} else {
+		h.transformSourceFiles(cfg, pkg, outDir, input.Opt_CopyOnly, input.Opt_CopyOnlyNot, input.Opt_TraceLocalModules, input.Opt_TracePkg, tracedDependencies, false)
 
-	execFile, err := filepath.Abs(filepath.Join(outDir, cfg.ExecutableFileName()))
-	panicIfError(err, "failed to get absolute path")
+		h.saveLibraryFiles(cfg, h.getModuleOutDir(pkg, outDir))
 
-	h.execGoBuild(append(input.Opt_GoBuildArg, "-o", execFile), packageDir, outDir)
+		buildTargets := h.getBuildTargets(cfg, pkg, outDir)
+		if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
+			h.saveGoModFile(cfg, outDir)
+		} else {
+			h.replaceDependencies(cfg, tracedDependencies, outDir, h.getCopiedGoModFile(pkg, outDir), true)
+		}
+
+		h.execGoBuild(append(getGoBuildArgs(mod, input.Opt_GoBuildArg), "-o", execFile), buildTargets, h.getModuleOutDir(pkg, outDir))
+	}
 
 	h.execBuiltFile(input, execFile)
 
@@ -209,6 +268,16 @@ func getTermWidth(termWidth int, isRun bool) int {
 	return termWidth
 }
 
+func getTraceFormat(format string) internal.TraceFormat {
+	switch traceFormat := internal.TraceFormat(format); traceFormat {
+	case internal.TraceFormat_Text, internal.TraceFormat_JSONL, internal.TraceFormat_Chrome:
+		return traceFormat
+	default:
+		log.Panicf("unknown format '%s'", format)
+		return ""
+	}
+}
+
 func generateUniqueString(seed int64) string {
 	if seed == 0 {
 		seed = time.Now().Unix()
@@ -222,6 +291,17 @@ func generateUniqueString(seed int64) string {
 	return string(v)
 }
 
+func compileRegexps(regexpStr []string) []*regexp.Regexp {
+	regexps := []*regexp.Regexp{}
+	for _, s := range regexpStr {
+		re, err := regexp.Compile(s)
+		panicIfError(err, "failed to compile regexp '%s'", s)
+
+		regexps = append(regexps, re)
+	}
+	return regexps
+}
+
 func (h cliHandler) resolvePackage(packageArg string) internal.ResolvedPackage {
 	pkg, err := internal.ResolvePackage(packageArg)
 	panicIfError(err, "failed to resolve package")
@@ -234,34 +314,88 @@ func (h *cliHandler) transformSourceFiles(
 	outDir string,
 	copyOnlyRegexpStr []string,
 	copyOnlyNotRegexpStr string,
-) {
+	traceLocalModules bool,
+	tracePkg []string,
+	tracedDependencies []tracedDependency,
+	rewrittenOnly bool,
+) (rewrittenFiles map[string]string) {
 	srcDir, sourceFiles := pkg.PackageDir, append([]string{}, pkg.SourceFiles...)
+	moduleFiles := map[string]bool{}
 	if pkg.ResolveType != internal.ResolveType_CommandLineArguments {
-		srcDir, sourceFiles = filepath.Dir(pkg.GoModFile), append(sourceFiles, pkg.GoModFile)
+		srcDir = pkg.RootDir
+		for _, file := range h.getModuleFiles(pkg) {
+			moduleFiles[file] = true
+			sourceFiles = append(sourceFiles, file)
+		}
+		for _, module := range pkg.LocalModules {
+			for _, file := range module.SourceFiles {
+				// The source files of the local modules are rewritten only if they are traced.
+				moduleFiles[file] = !traceLocalModules
+				sourceFiles = append(sourceFiles, file)
+			}
+		}
 	}
 
-	copyOnlyRegexp := []*regexp.Regexp{}
-	for _, s := range copyOnlyRegexpStr {
-		re, err := regexp.Compile(s)
-		panicIfError(err, "failed to compile regexp '%s'", s)
-
-		copyOnlyRegexp = append(copyOnlyRegexp, re)
+	// dstFiles holds the destinations of the source files of the dependency modules copied from the module cache.
+	dstFiles, tracedDependencyFiles := map[string]string{}, map[string]bool{}
+	for _, dependency := range tracedDependencies {
+		for file := range dependency.TracedFiles {
+			tracedDependencyFiles[file] = true
+		}
+		if dependency.Dir == "" {
+			// The vendored source files are rewritten in the vendor directory.
+			for file := range dependency.TracedFiles {
+				moduleFiles[file] = false
+			}
+			continue
+		}
+		dependencyOutDir := h.getDependencyOutDir(cfg, dependency.DependencyModule, outDir)
+		dstFiles[dependency.GoModFile] = filepath.Join(dependencyOutDir, "go.mod")
+		moduleFiles[dependency.GoModFile] = true
+		sourceFiles = append(sourceFiles, dependency.GoModFile)
+		for _, file := range dependency.SourceFiles {
+			relToFile, err := filepath.Rel(dependency.Dir, file)
+			panicIfError(err, "failed to get relative path")
+			dstFiles[file] = filepath.Join(dependencyOutDir, relToFile)
+			moduleFiles[file] = !dependency.TracedFiles[file]
+			sourceFiles = append(sourceFiles, file)
+		}
 	}
+
+	copyOnlyRegexp := compileRegexps(copyOnlyRegexpStr)
 	copyOnlyNotRegexp, err := regexp.Compile(copyOnlyNotRegexpStr)
 	panicIfError(err, "failed to compile regexp '%s'", copyOnlyNotRegexpStr)
 
+	cwd, err := os.Getwd()
+	panicIfError(err, "failed to get current directory")
+	tracePkgRegexp, err := internal.CompilePackagePatterns(tracePkg, pkg.Module, filepath.Dir(pkg.GoModFile), cwd)
+	panicIfError(err, "failed to compile package patterns")
+	isPackageTraced := func(file string) bool {
+		importPath, ok := pkg.ImportPaths[file]
+		return len(tracePkgRegexp) == 0 || (ok && lo.SomeBy(tracePkgRegexp, func(r *regexp.Regexp) bool { return r.MatchString(importPath) }))
+	}
+
+	rewrittenFiles = map[string]string{}
+	var mu sync.Mutex
 	eg, _ := errgroup.WithContext(context.Background())
 	for _, srcFile := range sourceFiles {
 		eg.Go(func() error {
-			isCopyOnly := (!copyOnlyNotRegexp.MatchString(srcFile)) ||
+			isCopyOnly := moduleFiles[srcFile] || (!tracedDependencyFiles[srcFile] && !isPackageTraced(srcFile)) || (!copyOnlyNotRegexp.MatchString(srcFile)) ||
 				lo.SomeBy(copyOnlyRegexp, func(r *regexp.Regexp) bool { return r.MatchString(srcFile) })
 			isGoSource := strings.HasSuffix(srcFile, ".go")
+			dstFile, isCopiedDependency := dstFiles[srcFile]
+			if rewrittenOnly && !(isGoSource && !isCopyOnly) && !isCopiedDependency {
+				// The original file is used as it is.
+				return nil
+			}
 
-			relToFile, err := filepath.Rel(srcDir, srcFile)
-			if err != nil {
-				return fmt.Errorf("failed to get relative path: %w", err)
+			if !isCopiedDependency {
+				relToFile, err := filepath.Rel(srcDir, srcFile)
+				if err != nil {
+					return fmt.Errorf("failed to get relative path: %w", err)
+				}
+				dstFile = filepath.Join(outDir, relToFile)
 			}
-			dstFile := filepath.Join(outDir, relToFile)
 
 			err = internal.TransformFile(srcFile, dstFile, func(r io.Reader, w io.Writer) (err error) {
 				if isGoSource && !isCopyOnly {
@@ -270,7 +404,12 @@ func (h *cliHandler) transformSourceFiles(
 					if _, err := io.Copy(src, r); err != nil {
 						return fmt.Errorf("failed to copy file: %w", err)
 					}
-					buf, err := internal.ProcessCode(cfg, srcFile, src.Bytes())
+					var buf []byte
+					if typed, ok := pkg.TypedFiles[srcFile]; ok {
+						buf, err = internal.ProcessTypedCode(cfg, typed, src.Bytes())
+					} else {
+						buf, err = internal.ProcessCode(cfg, srcFile, src.Bytes())
+					}
 					if err != nil {
 						return fmt.Errorf("failed to rewrite file: %w", err)
 					}
@@ -289,61 +428,315 @@ func (h *cliHandler) transformSourceFiles(
 			if err != nil {
 				return fmt.Errorf("failed to rewrite file: %w", err)
 			}
+			if isGoSource && !isCopyOnly && !isCopiedDependency {
+				// The files in the module cache cannot be overlaid, and the copied dependency modules are used via replace directives.
+				mu.Lock()
+				defer mu.Unlock()
+				rewrittenFiles[srcFile] = dstFile
+			}
 			return nil
 		})
 	}
 
 	err = eg.Wait()
 	panicIfError(err, "failed to wait")
+	return rewrittenFiles
 }
 
-func (h *cliHandler) saveLibraryFiles(cfg internal.Config, outDir string) {
+func (h *cliHandler) saveLibraryFiles(cfg internal.Config, outDir string) (libraryFile string) {
 	dst := filepath.Join(outDir, cfg.LibraryFileName())
 	if cfg.ResolveType != internal.ResolveType_CommandLineArguments {
 		dst = filepath.Join(outDir, cfg.LibraryPackageName(), cfg.LibraryFileName())
 	}
 
 	buf := bytes.NewBuffer(nil)
-	err := internal.GetLibraryCode(cfg.LibraryPackageName(), cfg.UniqueString, buf)
+	err := internal.GetLibraryCode(cfg, buf)
 	panicIfError(err, "failed to generate library")
 
 	h.logf("[add] %s", dst)
 	err = internal.SaveFile(dst, buf.String())
 	panicIfError(err, "failed to save library")
+	return dst
+}
+
+// prepareOverlayBuild writes only the rewritten source files, the generated library, and overlay.json into outDir.
+// It returns the path to overlay.json, the build targets, and the directory to execute go build in place.
+func (h *cliHandler) prepareOverlayBuild(
+	cfg internal.Config,
+	pkg internal.ResolvedPackage,
+	outDir string,
+	copyOnlyRegexpStr []string,
+	copyOnlyNotRegexpStr string,
+	traceLocalModules bool,
+	tracePkg []string,
+	tracedDependencies []tracedDependency,
+) (overlayFile string, buildTargets []string, buildDir string) {
+	outDir, err := filepath.Abs(outDir)
+	panicIfError(err, "failed to get absolute path")
+
+	replace := h.transformSourceFiles(cfg, pkg, outDir, copyOnlyRegexpStr, copyOnlyNotRegexpStr, traceLocalModules, tracePkg, tracedDependencies, true)
+
+	if lo.SomeBy(tracedDependencies, func(dependency tracedDependency) bool { return dependency.Dir != "" }) {
+		// go.mod, or go.work in workspace mode, is overlaid with the copy having the replace directives of the traced dependencies.
+		goModFile := pkg.GoModFile
+		if pkg.GoWorkFile != "" {
+			goModFile = pkg.GoWorkFile
+		}
+		overlayGoModFile := filepath.Join(outDir, "overlay."+filepath.Base(goModFile))
+		err := internal.TransformFile(goModFile, overlayGoModFile, func(r io.Reader, w io.Writer) error {
+			_, err := io.Copy(w, r)
+			return err
+		})
+		panicIfError(err, "failed to copy %s", goModFile)
+		h.replaceDependencies(cfg, tracedDependencies, outDir, overlayGoModFile, false)
+		replace[goModFile] = overlayGoModFile
+	}
+
+	// The library is placed virtually in the package directory or in the module root directory.
+	buildDir = pkg.PackageDir
+	libraryFile := filepath.Join(pkg.PackageDir, cfg.LibraryFileName())
+	if pkg.ResolveType != internal.ResolveType_CommandLineArguments {
+		buildDir = filepath.Dir(pkg.GoModFile)
+		libraryFile = filepath.Join(buildDir, cfg.LibraryPackageName(), cfg.LibraryFileName())
+	}
+	replace[libraryFile] = h.saveLibraryFiles(cfg, outDir)
+
+	overlayFile = filepath.Join(outDir, "overlay.json")
+	overlayJSON, err := json.MarshalIndent(map[string]any{"Replace": replace}, "", "  ")
+	panicIfError(err, "failed to marshal overlay")
+	h.logf("[add] %s", overlayFile)
+	err = internal.SaveFile(overlayFile, string(overlayJSON))
+	panicIfError(err, "failed to save overlay file")
+
+	switch pkg.ResolveType {
+	case internal.ResolveType_CommandLineArguments, internal.ResolveType_CommandLineArguments_Module:
+		// Source files are passed to go build as they are so that their build constraints are ignored.
+		for _, file := range pkg.SourceFiles {
+			if filepath.Dir(file) == pkg.PackageDir && strings.HasSuffix(file, ".go") {
+				buildTargets = append(buildTargets, file)
+			}
+		}
+		if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
+			buildTargets = append(buildTargets, libraryFile)
+		}
+	default:
+		buildTargets = []string{pkg.PackageDir}
+	}
+	return overlayFile, buildTargets, buildDir
+}
+
+// getModuleFiles returns go.mod, go.sum, and the files in the vendor directory of the module,
+// go.work and go.work.sum of the workspace, and go.mod and go.sum of the local modules, which are copied as they are.
+func (h *cliHandler) getModuleFiles(pkg internal.ResolvedPackage) []string {
+	moduleDir := filepath.Dir(pkg.GoModFile)
+	candidates := []string{filepath.Join(moduleDir, "go.sum")}
+	if pkg.GoWorkFile != "" {
+		candidates = append(candidates, pkg.GoWorkFile, pkg.GoWorkFile+".sum")
+	}
+	for _, module := range pkg.LocalModules {
+		candidates = append(candidates, module.GoModFile, filepath.Join(module.Dir, "go.sum"))
+	}
+	files := []string{pkg.GoModFile}
+	for _, file := range candidates {
+		if fileExists(file) {
+			files = append(files, file)
+		}
+	}
+	vendorDir := filepath.Join(moduleDir, "vendor")
+	if fileExists(filepath.Join(vendorDir, "modules.txt")) {
+		err := filepath.WalkDir(vendorDir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				files = append(files, path)
+			}
+			return nil
+		})
+		panicIfError(err, "failed to walk vendor directory")
+	}
+	return files
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
 }
 
 func (h *cliHandler) saveGoModFile(cfg internal.Config, outDir string) {
 	dst := filepath.Join(outDir, "go.mod")
 	panicIf(cfg.ResolveType != internal.ResolveType_CommandLineArguments, "go.mod is not required")
 
+	// The go directive is required because the generated library uses generics.
+	goMod := fmt.Sprintf("module xtracego_tmp_%s\n", cfg.UniqueString)
+	if goVersion := getGoVersion(); goVersion != "" {
+		goMod += fmt.Sprintf("\ngo %s\n", goVersion)
+	}
+
 	h.logf("[add] %s", dst)
-	err := internal.SaveFile(dst, fmt.Sprintf(`module xtracego_tmp_%s`, cfg.UniqueString))
+	err := internal.SaveFile(dst, goMod)
 	panicIfError(err, "failed to save go.mod file")
 }
 
-func (h cliHandler) getBuildPackageDir(pkg internal.ResolvedPackage, outDir string) string {
+// getGoVersion returns the version of the go command such as 1.25.0, or an empty string if it is not a release version.
+func getGoVersion() string {
+	out, err := exec.Command("go", "env", "GOVERSION").Output()
+	panicIfError(err, "failed to run go env GOVERSION")
+	goVersion, _, _ := strings.Cut(strings.TrimSpace(string(out)), " ")
+	goVersion, ok := strings.CutPrefix(goVersion, "go")
+	if !ok || !regexp.MustCompile(`^1\.\d+(\.\d+)?$`).MatchString(goVersion) {
+		return ""
+	}
+	return goVersion
+}
+
+// getGoBuildArgs returns the arguments for go build with -mod if it is specified.
+func getGoBuildArgs(mod string, goBuildArgs []string) []string {
+	if mod == "" {
+		return goBuildArgs
+	}
+	return append([]string{"-mod=" + mod}, goBuildArgs...)
+}
+
+// getMod validates the value of -mod, which is empty or one of mod, readonly, and vendor.
+func getMod(mod string) string {
+	panicIf(!lo.Contains([]string{"", "mod", "readonly", "vendor"}, mod), "invalid value of -mod: %q", mod)
+	return mod
+}
+
+// getModuleOutDir returns the directory in outDir where the main module is placed, which is also used to execute go build.
+func (h cliHandler) getModuleOutDir(pkg internal.ResolvedPackage, outDir string) string {
 	if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
-		return "."
+		return outDir
+	}
+	relToModule, err := filepath.Rel(pkg.RootDir, filepath.Dir(pkg.GoModFile))
+	panicIfError(err, "failed to get relative path")
+	return filepath.Join(outDir, relToModule)
+}
+
+// tracedDependency is a dependency module providing the packages to be traced.
+type tracedDependency struct {
+	internal.DependencyModule
+	// TracedFiles holds the Go source files to be rewritten in the module.
+	TracedFiles map[string]bool
+}
+
+// getTracedDependencies returns the dependency modules providing the packages whose import paths match the patterns,
+// and loads the type information of these packages.
+func (h cliHandler) getTracedDependencies(pkg internal.ResolvedPackage, traceDep []string) []tracedDependency {
+	if len(traceDep) == 0 {
+		return nil
 	}
 	cwd, err := os.Getwd()
 	panicIfError(err, "failed to get current directory")
-	relToPkg, err := filepath.Rel(cwd, pkg.PackageDir)
+	traceDepRegexp, err := internal.CompilePackagePatterns(traceDep, pkg.Module, filepath.Dir(pkg.GoModFile), cwd)
+	panicIfError(err, "failed to compile package patterns")
+
+	tracedDependencies, importPaths := []tracedDependency{}, []string{}
+	for _, module := range pkg.DependencyModules {
+		dependency := tracedDependency{DependencyModule: module, TracedFiles: map[string]bool{}}
+		for _, file := range module.SourceFiles {
+			importPath := pkg.ImportPaths[file]
+			if strings.HasSuffix(file, ".go") && lo.SomeBy(traceDepRegexp, func(r *regexp.Regexp) bool { return r.MatchString(importPath) }) {
+				dependency.TracedFiles[file] = true
+				importPaths = append(importPaths, importPath)
+			}
+		}
+		if len(dependency.TracedFiles) > 0 {
+			h.logf("[trace] %s %s", module.Path, module.Version)
+			tracedDependencies = append(tracedDependencies, dependency)
+		}
+	}
+	panicIf(len(tracedDependencies) == 0, "no dependencies match -trace-dep %q", traceDep)
+
+	err = pkg.LoadDependencyTypes(lo.Uniq(importPaths))
+	panicIfError(err, "failed to load types of dependencies")
+	return tracedDependencies
+}
+
+// getDependencyOutDir returns the directory in outDir where the dependency module is copied from the module cache.
+func (h cliHandler) getDependencyOutDir(cfg internal.Config, module internal.DependencyModule, outDir string) string {
+	// The version is not included in the path because only one version of each module is used in the build.
+	return filepath.Join(outDir, cfg.DependencyDirName(), filepath.FromSlash(module.Path))
+}
+
+// getCopiedGoModFile returns the path to go.mod of the main module, or go.work in workspace mode, copied to outDir.
+func (h cliHandler) getCopiedGoModFile(pkg internal.ResolvedPackage, outDir string) string {
+	goModFile := pkg.GoModFile
+	if pkg.GoWorkFile != "" {
+		goModFile = pkg.GoWorkFile
+	}
+	relToFile, err := filepath.Rel(pkg.RootDir, goModFile)
 	panicIfError(err, "failed to get relative path")
-	outDir, err = filepath.Abs(outDir)
-	panicIfError(err, "failed to get absolute path")
-	return filepath.Join(outDir, relToPkg)
+	return filepath.Join(outDir, relToFile)
 }
 
-func (h cliHandler) execGoModTidy(outDir string) {
-	cmd := exec.Command("go", "mod", "tidy")
-	cmd.Dir, cmd.Stdout, cmd.Stderr, cmd.Stdin = outDir, os.Stdout, os.Stderr, os.Stdin
-	h.logf("[exec] %s [%s]", cmd.String(), cmd.Dir)
+// replaceDependencies adds the replace directives of the traced dependencies with their copies in outDir to goModFile, which may be go.work.
+// The copies are specified by the paths relative to the directory of goModFile if relative is true, or by the absolute paths otherwise.
+// The vendored dependencies are not replaced because they are rewritten in the vendor directory.
+func (h cliHandler) replaceDependencies(cfg internal.Config, tracedDependencies []tracedDependency, outDir string, goModFile string, relative bool) {
+	subcommand := "mod"
+	if strings.HasSuffix(goModFile, "go.work") {
+		subcommand = "work"
+	}
+	args := []string{subcommand, "edit"}
+	for _, dependency := range tracedDependencies {
+		if dependency.Dir == "" {
+			continue
+		}
+		dependencyOutDir, err := filepath.Abs(h.getDependencyOutDir(cfg, dependency.DependencyModule, outDir))
+		panicIfError(err, "failed to get absolute path")
+		if relative {
+			goModDir, err := filepath.Abs(filepath.Dir(goModFile))
+			panicIfError(err, "failed to get absolute path")
+			dependencyOutDir, err = filepath.Rel(goModDir, dependencyOutDir)
+			panicIfError(err, "failed to get relative path")
+			dependencyOutDir = "./" + filepath.ToSlash(dependencyOutDir)
+		}
+		args = append(args, fmt.Sprintf("-replace=%s@%s=%s", dependency.Path, dependency.Version, dependencyOutDir))
+	}
+	if len(args) == 2 {
+		return
+	}
+	cmd := exec.Command("go", append(args, goModFile)...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	h.logf("[exec] %s", cmd.String())
 	err := cmd.Run()
-	panicIfError(err, "failed to run go mod tidy")
+	panicIfError(err, "failed to run go %s edit", subcommand)
+}
+
+func (h cliHandler) getBuildTargets(cfg internal.Config, pkg internal.ResolvedPackage, outDir string) []string {
+	switch pkg.ResolveType {
+	case internal.ResolveType_CommandLineArguments, internal.ResolveType_CommandLineArguments_Module:
+		// Source files are passed to go build as they are so that their build constraints are ignored.
+		outDir, err := filepath.Abs(outDir)
+		panicIfError(err, "failed to get absolute path")
+		srcDir, targets := pkg.PackageDir, []string{}
+		if pkg.ResolveType == internal.ResolveType_CommandLineArguments {
+			targets = append(targets, filepath.Join(outDir, cfg.LibraryFileName()))
+		} else {
+			srcDir = pkg.RootDir
+		}
+		for _, file := range pkg.SourceFiles {
+			if filepath.Dir(file) != pkg.PackageDir || !strings.HasSuffix(file, ".go") {
+				continue
+			}
+			relToFile, err := filepath.Rel(srcDir, file)
+			panicIfError(err, "failed to get relative path")
+			targets = append(targets, filepath.Join(outDir, relToFile))
+		}
+		return targets
+	default:
+		relToPkg, err := filepath.Rel(pkg.RootDir, pkg.PackageDir)
+		panicIfError(err, "failed to get relative path")
+		outDir, err = filepath.Abs(outDir)
+		panicIfError(err, "failed to get absolute path")
+		return []string{filepath.Join(outDir, relToPkg)}
+	}
 }
 
-func (h cliHandler) execGoBuild(buildArgs []string, buildPackageDir string, outDir string) {
-	args := append(append([]string{"build"}, buildArgs...), buildPackageDir)
+func (h cliHandler) execGoBuild(buildArgs []string, buildTargets []string, outDir string) {
+	args := append(append([]string{"build"}, buildArgs...), buildTargets...)
 	cmd := exec.Command("go", args...)
 	cmd.Dir, cmd.Stdout, cmd.Stderr, cmd.Stdin = outDir, os.Stdout, os.Stderr, os.Stdin
 	h.logf("[exec] %s [%s]", cmd.String(), cmd.Dir)
@@ -0,0 +1,51 @@
+//go:build ignore
+
+// examples/goargs/main.go
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type Flag bool
+
+func pair() (int, string) { return 1, "one" }
+
+func printPair(wg *sync.WaitGroup) func(int, string) {
+	return func(n int, s string) {
+		defer wg.Done()
+		fmt.Println(n, s)
+	}
+}
+
+func printInt64(n int64, wg *sync.WaitGroup) {
+	defer wg.Done()
+	fmt.Println(n)
+}
+
+func printFlag(b Flag, d time.Duration, wg *sync.WaitGroup) {
+	defer wg.Done()
+	fmt.Println(b, d)
+}
+
+func main() {
+	var wg sync.WaitGroup
+	s := 3
+
+	// The results of the only argument are passed to the parameters.
+	wg.Add(1)
+	go printPair(&wg)(pair())
+	wg.Wait()
+
+	// The untyped shifts and comparisons are typed by the parameters.
+	wg.Add(1)
+	go printInt64(1<<s, &wg)
+	wg.Wait()
+
+	wg.Add(1)
+	go printFlag(s > 1, 1<<s, &wg)
+	s = 0
+	wg.Wait()
+}
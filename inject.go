@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand/v2"
+	"regexp"
 
 	"github.com/Jumpaku/xtracego/internal"
 )
@@ -16,6 +17,7 @@ func NewInjector() *injector {
 	return &injector{
 		cfg: internal.Config{
 			ResolveType: internal.ResolveType_CommandLineArguments,
+			TraceFormat: internal.TraceFormat_Text,
 		},
 	}
 }
@@ -25,6 +27,7 @@ func NewInjectorWithModule(moduleName string) *injector {
 		cfg: internal.Config{
 			ModuleName:  moduleName,
 			ResolveType: internal.ResolveType_PackageDirectory_Module,
+			TraceFormat: internal.TraceFormat_Text,
 		},
 	}
 }
@@ -57,6 +60,42 @@ func (i *injector) WithTraceCall(traceCall bool) *injector {
 	return i
 }
 
+// WithTraceChan enables tracing sending to, receiving from, and closing channels.
+func (i *injector) WithTraceChan(traceChan bool) *injector {
+	i.cfg.TraceChan = traceChan
+	return i
+}
+
+// WithTraceExternCalls enables tracing the arguments and the results of calls of functions in packages outside the module.
+func (i *injector) WithTraceExternCalls(traceExternCalls bool) *injector {
+	i.cfg.TraceExternCalls = traceExternCalls
+	return i
+}
+
+// WithTraceErrorsOnly restricts tracing returns to printing the non-nil errors returned from functions.
+func (i *injector) WithTraceErrorsOnly(traceErrorsOnly bool) *injector {
+	i.cfg.TraceErrorsOnly = traceErrorsOnly
+	return i
+}
+
+// WithTraceCond enables tracing the values of if conditions and switch tags, and the dynamic types of type switches.
+func (i *injector) WithTraceCond(traceCond bool) *injector {
+	i.cfg.TraceCond = traceCond
+	return i
+}
+
+// WithIncludeFunc specifies functions to be traced by regular expressions matching names such as pkg.Func and pkg.(*T).Method.
+func (i *injector) WithIncludeFunc(includeFunc ...*regexp.Regexp) *injector {
+	i.cfg.IncludeFunc = includeFunc
+	return i
+}
+
+// WithExcludeFunc specifies functions not to be traced by regular expressions matching names such as pkg.Func and pkg.(*T).Method.
+func (i *injector) WithExcludeFunc(excludeFunc ...*regexp.Regexp) *injector {
+	i.cfg.ExcludeFunc = excludeFunc
+	return i
+}
+
 func (i *injector) WithShowGoroutine(showGoroutine bool) *injector {
 	i.cfg.ShowGoroutine = showGoroutine
 	return i
@@ -67,11 +106,29 @@ func (i *injector) WithShowTimestamp(showTimestamp bool) *injector {
 	return i
 }
 
+func (i *injector) WithIndent(indent bool) *injector {
+	i.cfg.Indent = indent
+	return i
+}
+
 func (i *injector) WithLineWidth(lineWidth int) *injector {
 	i.cfg.LineWidth = lineWidth
 	return i
 }
 
+// WithTraceFormat sets the format of trace messages, which is "text", "jsonl", or "chrome".
+func (i *injector) WithTraceFormat(traceFormat string) *injector {
+	i.cfg.TraceFormat = internal.TraceFormat(traceFormat)
+	return i
+}
+
+// WithTraceOutput sets the destination of trace messages, which is a file path, "fd:N", or "unix:/path/to/socket".
+// The standard error is used if it is empty.
+func (i *injector) WithTraceOutput(traceOutput string) *injector {
+	i.cfg.TraceOutput = traceOutput
+	return i
+}
+
 func (i *injector) InjectXtrace(src io.Reader, dst io.Writer) (err error) {
 	srcBytes, err := io.ReadAll(src)
 	if err != nil {
@@ -88,7 +145,7 @@ func (i *injector) InjectXtrace(src io.Reader, dst io.Writer) (err error) {
 }
 
 func (i *injector) GenerateLogger(dst io.Writer) (err error) {
-	return internal.GetLibraryCode(i.cfg.LibraryPackageName(), i.cfg.UniqueString, dst)
+	return internal.GetLibraryCode(i.cfg, dst)
 }
 
 func (i *injector) GenerateGoMod(dst io.Writer) (err error) {
@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"go/ast"
+	"go/token"
+	"reflect"
+)
+
+// cloneNode returns a deep copy of the node without positions,
+// which can be injected as a new node even if the original node remains in the file.
+func cloneNode[N ast.Node](node N) N {
+	return cloneValue(reflect.ValueOf(node)).Interface().(N)
+}
+
+var posType = reflect.TypeOf(token.NoPos)
+
+func cloneValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Elem().Type())
+		clone.Elem().Set(cloneValue(v.Elem()))
+		return clone
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.New(v.Type()).Elem()
+		clone.Set(cloneValue(v.Elem()))
+		return clone
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		clone := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			clone.Index(i).Set(cloneValue(v.Index(i)))
+		}
+		return clone
+	case reflect.Struct:
+		clone := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).Type != posType {
+				clone.Field(i).Set(cloneValue(v.Field(i)))
+			}
+		}
+		return clone
+	default:
+		return v
+	}
+}
@@ -1,15 +1,48 @@
 package internal
 
+import "regexp"
+
+type TraceFormat string
+
+const (
+	// TraceFormat_Text Each trace is printed as a human-readable line padded to the line width.
+	TraceFormat_Text TraceFormat = "text"
+
+	// TraceFormat_JSONL Each trace is printed as a JSON object in a line.
+	TraceFormat_JSONL TraceFormat = "jsonl"
+
+	// TraceFormat_Chrome Calling and returning functions are printed as events of Chrome Trace Event Format.
+	// Basic statements and variables are not printed.
+	TraceFormat_Chrome TraceFormat = "chrome"
+)
+
 type Config struct {
 	TraceStmt bool
 	TraceVar  bool
 	TraceCall bool
+	// TraceCond enables tracing the values of if conditions, switch tags, and the dynamic types of type switches.
+	TraceCond bool
+	// TraceChan enables tracing sending to, receiving from, and closing channels.
+	TraceChan bool
+	// TraceExternCalls enables tracing the arguments and the results of calls of functions in packages outside the module.
+	TraceExternCalls bool
+	// TraceErrorsOnly restricts tracing returns to printing the non-nil results of error types.
+	TraceErrorsOnly bool
+
+	// IncludeFunc and ExcludeFunc filter functions to be traced by their names such as pkg.Func and pkg.(*T).Method.
+	// If IncludeFunc is not empty, only functions matching one of them are traced.
+	// Functions matching one of ExcludeFunc are not traced.
+	IncludeFunc []*regexp.Regexp
+	ExcludeFunc []*regexp.Regexp
 
 	ShowTimestamp bool
 	ShowGoroutine bool
+	Indent        bool
 
 	UniqueString string
 	LineWidth    int
+	TraceFormat  TraceFormat
+	TraceOutput  string
 
 	ResolveType ResolveType
 	ModuleName  string
@@ -33,6 +66,11 @@ func (cfg *Config) LibraryFileName() string {
 	return "xtracego_" + cfg.UniqueString + ".go"
 }
 
+// DependencyDirName returns the name of the directory where the traced dependency modules are copied from the module cache.
+func (cfg *Config) DependencyDirName() string {
+	return "xtracego_deps_" + cfg.UniqueString
+}
+
 func (cfg *Config) ExecutableFileName() string {
 	return "main_" + cfg.UniqueString
 }
@@ -53,6 +91,14 @@ func (cfg *Config) IdentifierPrintlnVariable() string {
 	return cfg.LibraryPackageName() + "." + funcName
 }
 
+func (cfg *Config) IdentifierPrintlnTypedVariable() string {
+	funcName := "PrintlnTypedVariable_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
 func (cfg *Config) IdentifierPrintlnReturnVariable() string {
 	funcName := "PrintlnReturnVariable_" + cfg.UniqueString
 	if cfg.ResolveType == ResolveType_CommandLineArguments {
@@ -76,3 +122,83 @@ func (cfg *Config) IdentifierPrintlnReturn() string {
 	}
 	return cfg.LibraryPackageName() + "." + funcName
 }
+
+func (cfg *Config) IdentifierPrintlnGo() string {
+	funcName := "PrintlnGo_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnRecover() string {
+	funcName := "PrintlnRecover_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnCondition() string {
+	funcName := "PrintlnCondition_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnType() string {
+	funcName := "PrintlnType_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnSend() string {
+	funcName := "PrintlnSend_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnRecv() string {
+	funcName := "PrintlnRecv_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnRecvOk() string {
+	funcName := "PrintlnRecvOk_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnClose() string {
+	funcName := "PrintlnClose_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnExternCall() string {
+	funcName := "PrintlnExternCall_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
+
+func (cfg *Config) IdentifierPrintlnReturnError() string {
+	funcName := "PrintlnReturnError_" + cfg.UniqueString
+	if cfg.ResolveType == ResolveType_CommandLineArguments {
+		return funcName
+	}
+	return cfg.LibraryPackageName() + "." + funcName
+}
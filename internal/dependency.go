@@ -0,0 +1,39 @@
+package internal
+
+import (
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// DependencyModule is a module providing dependencies whose source files are in the module cache or the vendor directory.
+type DependencyModule struct {
+	Path    string
+	Version string
+	// Dir is the directory of the module in the module cache, which is empty if the module is vendored.
+	Dir string
+	// GoModFile is the path to go.mod of the module in the module cache, which is empty if the module is vendored.
+	GoModFile string
+	// SourceFiles holds the source files of the packages used in the build, including assembly and embedded files.
+	SourceFiles []string
+}
+
+// getDependencyModule returns the module of the package if its source files are not local but in the module cache or the vendor directory.
+func getDependencyModule(module *packages.Module) (dependency DependencyModule, ok bool) {
+	if module == nil || module.Main || getLocalModuleDir(module) != "" {
+		return DependencyModule{}, false
+	}
+	dependency = DependencyModule{Path: module.Path, Version: module.Version, Dir: module.Dir, GoModFile: module.GoMod}
+	if module.Replace != nil {
+		dependency.Dir, dependency.GoModFile = module.Replace.Dir, module.Replace.GoMod
+	}
+	return dependency, true
+}
+
+// LoadDependencyTypes parses and type-checks the packages of the dependencies so that their source files are rewritten with type information.
+func (p ResolvedPackage) LoadDependencyTypes(importPaths []string) error {
+	if len(importPaths) == 0 || p.GoModFile == "" {
+		return nil
+	}
+	return loadTypedFiles(p.TypedFiles, filepath.Dir(p.GoModFile), importPaths)
+}
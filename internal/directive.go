@@ -0,0 +1,159 @@
+package internal
+
+import (
+	"go/ast"
+	"strings"
+)
+
+// Directive is a magic comment which controls the injection into the node it is attached to:
+//   - //xtrace:off suppresses the injection into the node,
+//   - //xtrace:on re-enables the injection into the node suppressed by an outer //xtrace:off,
+//   - //xtrace:novar hides the values of variables in the node,
+//   - //xtrace:novar name1 name2 ... hides the values of the specified variables in the node.
+//
+// A directive is attached to a file, declaration, or statement if the comment group containing the directive
+// is placed on the line immediately before the node or trails on the line where the node begins.
+type Directive struct {
+	Kind  string
+	Names []string
+}
+
+const (
+	DirectiveOff   = "xtrace:off"
+	DirectiveOn    = "xtrace:on"
+	DirectiveNoVar = "xtrace:novar"
+)
+
+type directiveScope struct {
+	node       ast.Node
+	off        bool
+	noVarAll   bool
+	noVarNames map[string]bool
+}
+
+func parseDirective(text string) (Directive, bool) {
+	if !strings.HasPrefix(text, "//xtrace:") {
+		return Directive{}, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(text, "//"))
+	switch fields[0] {
+	case DirectiveOff, DirectiveOn, DirectiveNoVar:
+		return Directive{Kind: fields[0], Names: fields[1:]}, true
+	default:
+		return Directive{}, false
+	}
+}
+
+// collectDirectives returns the directives attached to files, declarations, and statements.
+func (x *Xtrace) collectDirectives() map[ast.Node][]Directive {
+	directivesByLine := map[int][]Directive{}
+	for _, group := range x.file.Comments {
+		directives := []Directive{}
+		for _, c := range group.List {
+			if d, ok := parseDirective(c.Text); ok {
+				directives = append(directives, d)
+			}
+		}
+		if len(directives) == 0 {
+			continue
+		}
+		line := x.fset.Position(group.Pos()).Line
+		if x.startsLine(group.Pos()) {
+			line = x.fset.Position(group.End()).Line + 1
+		}
+		directivesByLine[line] = append(directivesByLine[line], directives...)
+	}
+
+	directivesByNode := map[ast.Node][]Directive{}
+	ast.Inspect(x.file, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.File, ast.Decl, ast.Stmt:
+			// The directives are attached to the outermost node beginning at the line.
+			line := x.fset.Position(n.Pos()).Line
+			if directives, ok := directivesByLine[line]; ok {
+				directivesByNode[n] = directives
+				delete(directivesByLine, line)
+			}
+		}
+		return true
+	})
+	return directivesByNode
+}
+
+func (x *Xtrace) currentScope() directiveScope {
+	if len(x.directiveScopes) == 0 {
+		return directiveScope{}
+	}
+	return x.directiveScopes[len(x.directiveScopes)-1]
+}
+
+// enterNode starts the scope of the directives attached to the node.
+func (x *Xtrace) enterNode(node ast.Node) {
+	directives, ok := x.directives[node]
+	if !ok {
+		return
+	}
+	outer := x.currentScope()
+	scope := directiveScope{node: node, off: outer.off, noVarAll: outer.noVarAll, noVarNames: map[string]bool{}}
+	for name := range outer.noVarNames {
+		scope.noVarNames[name] = true
+	}
+	for _, d := range directives {
+		switch d.Kind {
+		case DirectiveOff:
+			scope.off = true
+		case DirectiveOn:
+			scope.off = false
+		case DirectiveNoVar:
+			if len(d.Names) == 0 {
+				scope.noVarAll = true
+			}
+			for _, name := range d.Names {
+				scope.noVarNames[name] = true
+			}
+		}
+	}
+	x.directiveScopes = append(x.directiveScopes, scope)
+}
+
+// leaveNode ends the scope of the directives attached to the node.
+func (x *Xtrace) leaveNode(node ast.Node) {
+	if n := len(x.directiveScopes); n > 0 && x.directiveScopes[n-1].node == node {
+		x.directiveScopes = x.directiveScopes[:n-1]
+	}
+}
+
+func (x *Xtrace) isTraceOff() bool {
+	return x.currentScope().off
+}
+
+// isVarHidden returns whether the value labeled with the name is hidden.
+// The label such as cfg.Password and *p is also hidden if its root variable is hidden.
+func (x *Xtrace) isVarHidden(name string) bool {
+	scope := x.currentScope()
+	root, _, _ := strings.Cut(strings.TrimLeft(name, "*("), ".")
+	root, _, _ = strings.Cut(root, "[")
+	root, _, _ = strings.Cut(root, ")")
+	return scope.noVarAll || scope.noVarNames[name] || scope.noVarNames[root]
+}
+
+// isExprHidden returns whether the values of the expressions are hidden,
+// which is the case if one of the variables or the labels such as cfg.Password referred in them is hidden.
+func (x *Xtrace) isExprHidden(exprs ...ast.Expr) bool {
+	hidden := x.currentScope().noVarAll
+	for _, expr := range exprs {
+		if expr == nil {
+			continue
+		}
+		ast.Inspect(expr, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.Ident:
+				hidden = hidden || x.isVarHidden(n.Name)
+			case *ast.SelectorExpr, *ast.StarExpr, *ast.IndexExpr:
+				hidden = hidden || x.isVarHidden(x.sourceOf(n.(ast.Expr)))
+			}
+			return !hidden
+		})
+	}
+	return hidden
+}
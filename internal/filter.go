@@ -0,0 +1,49 @@
+package internal
+
+import (
+	"go/ast"
+	"regexp"
+
+	"github.com/samber/lo"
+)
+
+// isFuncTraced returns whether the function with the name is traced according to IncludeFunc and ExcludeFunc.
+func (x *Xtrace) isFuncTraced(funcName string) bool {
+	matches := func(r *regexp.Regexp) bool { return r.MatchString(funcName) }
+	if len(x.IncludeFunc) > 0 && !lo.SomeBy(x.IncludeFunc, matches) {
+		return false
+	}
+	return !lo.SomeBy(x.ExcludeFunc, matches)
+}
+
+// funcName returns the name of the function in the same form as the runtime, such as pkg.Func, pkg.T.Method, and pkg.(*T).Method.
+// Declarations at the package level are named pkg.init.
+func (x *Xtrace) funcName(decl *ast.FuncDecl) string {
+	pkg := x.file.Name.Name
+	if decl == nil {
+		return pkg + ".init"
+	}
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return pkg + "." + decl.Name.Name
+	}
+
+	recv, isPointer := decl.Recv.List[0].Type, false
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv, isPointer = star.X, true
+	}
+	typeParams := ""
+	switch r := recv.(type) {
+	case *ast.IndexExpr:
+		recv, typeParams = r.X, "[...]"
+	case *ast.IndexListExpr:
+		recv, typeParams = r.X, "[...]"
+	}
+	typeName := ""
+	if ident, ok := recv.(*ast.Ident); ok {
+		typeName = ident.Name + typeParams
+	}
+	if isPointer {
+		return pkg + ".(*" + typeName + ")." + decl.Name.Name
+	}
+	return pkg + "." + typeName + "." + decl.Name.Name
+}
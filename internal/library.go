@@ -11,14 +11,198 @@ package {{.PackageName}}
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"reflect"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 )
 
+const traceFormat = "{{.TraceFormat}}"
+
+const traceIndent = {{.Indent}}
+
+// traceOutput is the destination of trace messages, which is specified by XTRACEGO_OUTPUT or the -output option:
+// - empty: standard error,
+// - fd:N: the file descriptor N,
+// - unix:/path/to/socket: the Unix domain socket,
+// - otherwise: the file path.
+var traceOutput = openTraceOutput()
+
+func openTraceOutput() io.Writer {
+	output := {{printf "%q" .TraceOutput}}
+	if v, ok := os.LookupEnv("XTRACEGO_OUTPUT"); ok {
+		output = v
+	}
+	var w io.Writer
+	var err error
+	switch {
+	case output == "":
+		return os.Stderr
+	case strings.HasPrefix(output, "fd:"):
+		var fd int
+		if fd, err = strconv.Atoi(strings.TrimPrefix(output, "fd:")); err == nil {
+			w = os.NewFile(uintptr(fd), output)
+		}
+	case strings.HasPrefix(output, "unix:"):
+		w, err = net.Dial("unix", strings.TrimPrefix(output, "unix:"))
+	default:
+		w, err = os.Create(output)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "xtracego: failed to open trace output %q, so standard error is used: %v\n", output, err)
+		return os.Stderr
+	}
+	return w
+}
+
+type traceEvent struct {
+	Kind      string "json:\"kind\""
+	Timestamp string "json:\"timestamp,omitempty\""
+	Goroutine string "json:\"goroutine,omitempty\""
+	Function  string "json:\"function\""
+	File      string "json:\"file\""
+	Line      int    "json:\"line\""
+	Column    int    "json:\"column,omitempty\""
+	Source    string "json:\"source,omitempty\""
+	Name      string "json:\"name,omitempty\""
+	Value     string "json:\"value,omitempty\""
+	Type      string "json:\"type,omitempty\""
+	Parent    string "json:\"parent,omitempty\""
+	Child     string "json:\"child,omitempty\""
+	OK        *bool  "json:\"ok,omitempty\""
+	Depth     int    "json:\"depth,omitempty\""
+	ElapsedNs int64  "json:\"elapsed_ns,omitempty\""
+}
+
+func printJSONLine(stack int, event traceEvent, showTimestamp, showGoroutine bool) {
+	showTimestamp = getEnvOverride("XTRACEGO_TIMESTAMP", showTimestamp)
+	showGoroutine = getEnvOverride("XTRACEGO_GOROUTINE", showGoroutine)
+	if showTimestamp {
+		event.Timestamp = time.Now().In(time.UTC).Format(time.RFC3339Nano)
+	}
+	if showGoroutine {
+		event.Goroutine = getGoroutineId()
+	}
+	if event.Function == "" {
+		event.Function = getFuncName(stack)
+	}
+	event.Depth = getCallDepth()
+	if event.File == "" {
+		// The position where the Println* function is called.
+		_, event.File, event.Line, _ = runtime.Caller(2)
+	}
+	b, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_, _ = traceOutput.Write(append(b, '\n'))
+}
+
+// envOverrides holds the boolean environment variables which override the configuration of tracing at startup:
+// - XTRACEGO_TRACE: whether trace anything or not,
+// - XTRACEGO_TRACE_STMT: whether trace basic statements or not,
+// - XTRACEGO_TRACE_VAR: whether trace variables and constants or not,
+// - XTRACEGO_TRACE_CALL: whether trace calling and returning functions and methods or not,
+// - XTRACEGO_TRACE_COND: whether trace values of conditions or not,
+// - XTRACEGO_TRACE_CHAN: whether trace channel operations or not,
+// - XTRACEGO_TRACE_EXTERN: whether trace calls of functions outside the module or not,
+// - XTRACEGO_TRACE_ERROR: whether trace non-nil errors returned from functions or not,
+// - XTRACEGO_TIMESTAMP: whether show timestamp or not,
+// - XTRACEGO_GOROUTINE: whether show goroutine ID or not,
+// - XTRACEGO_INDENT: whether indent trace messages by call depth or not.
+var envOverrides = loadEnvOverrides()
+
+func loadEnvOverrides() map[string]bool {
+	overrides := map[string]bool{}
+	for _, name := range []string{"XTRACEGO_TRACE", "XTRACEGO_TRACE_STMT", "XTRACEGO_TRACE_VAR", "XTRACEGO_TRACE_CALL", "XTRACEGO_TRACE_COND", "XTRACEGO_TRACE_CHAN", "XTRACEGO_TRACE_EXTERN", "XTRACEGO_TRACE_ERROR", "XTRACEGO_TIMESTAMP", "XTRACEGO_GOROUTINE", "XTRACEGO_INDENT"} {
+		if v, ok := os.LookupEnv(name); ok {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				_, _ = fmt.Fprintf(os.Stderr, "xtracego: invalid boolean value of %s is ignored: %q\n", name, v)
+				continue
+			}
+			overrides[name] = b
+		}
+	}
+	return overrides
+}
+
+func getEnvOverride(name string, value bool) bool {
+	if v, ok := envOverrides[name]; ok {
+		return v
+	}
+	return value
+}
+
+func isTraceEnabled(name string) bool {
+	return getEnvOverride("XTRACEGO_TRACE", true) && getEnvOverride(name, true)
+}
+
+// callDepths holds the call depth of each goroutine, which is tracked only if indentation is enabled.
+var callDepths = struct {
+	sync.Mutex
+	depth map[string]int
+}{depth: map[string]int{}}
+
+func isIndentEnabled() bool {
+	return getEnvOverride("XTRACEGO_INDENT", traceIndent)
+}
+
+func addCallDepth(delta int) {
+	goroutineId := getGoroutineId()
+	callDepths.Lock()
+	defer callDepths.Unlock()
+	if depth := callDepths.depth[goroutineId] + delta; depth > 0 {
+		callDepths.depth[goroutineId] = depth
+	} else {
+		delete(callDepths.depth, goroutineId)
+	}
+}
+
+func getCallDepth() int {
+	if !isIndentEnabled() {
+		return 0
+	}
+	goroutineId := getGoroutineId()
+	callDepths.Lock()
+	defer callDepths.Unlock()
+	return callDepths.depth[goroutineId]
+}
+
+var chromeTraceStart = time.Now()
+
+var chromeTraceOpen sync.Once
+
+// printChromeTraceEvent prints an event in Chrome Trace Event Format, which can be opened by chrome://tracing or Perfetto.
+// The events are printed as elements of a JSON array whose closing bracket is omitted, which is allowed by the format.
+func printChromeTraceEvent(funcName string, phase, signature string) {
+	chromeTraceOpen.Do(func() {
+		_, _ = traceOutput.Write([]byte("[\n"))
+	})
+	tid, _ := strconv.Atoi(getGoroutineId())
+	b, err := json.Marshal(map[string]any{
+		"name": signature,
+		"cat":  "function",
+		"ph":   phase,
+		"ts":   float64(time.Since(chromeTraceStart).Nanoseconds()) / 1000,
+		"pid":  os.Getpid(),
+		"tid":  tid,
+		"args": map[string]any{"function": funcName},
+	})
+	if err != nil {
+		return
+	}
+	_, _ = traceOutput.Write(append(b, ',', '\n'))
+}
+
 func getTimestamp() string {
 	return time.Now().In(time.UTC).Format(time.RFC3339)
 }
@@ -27,14 +211,54 @@ func getGoroutineId() string {
 	return string(bytes.Split(debug.Stack(), []byte(" "))[1])
 }
 
+// getTracedFunc returns the traced function which deferred the function at the stack, and the position where it returns or panics.
+// The traced function is not always the caller of the deferred function, which is called by the runtime while panicking,
+// so it is searched by the name of the deferred function literal, such as main.f.func1 for main.f and main.f.func1.2 for main.f.func1.
+func getTracedFunc(stack int) (funcName string, file string, line int) {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(stack+2, pcs)])
+	deferred, more := frames.Next()
+	if i := strings.LastIndex(deferred.Function, "."); i >= 0 {
+		funcName = deferred.Function[:i]
+	}
+	for more {
+		var frame runtime.Frame
+		frame, more = frames.Next()
+		if frame.Function == funcName {
+			return funcName, frame.File, frame.Line
+		}
+	}
+	return funcName, deferred.File, deferred.Line
+}
+
 func getFuncName(stack int) string {
 	pc, _, _, _ := runtime.Caller(stack)
-	name := runtime.FuncForPC(pc).Name()
-	vs := strings.Split(name, "/")
+	return trimPackagePath(runtime.FuncForPC(pc).Name())
+}
+
+func trimPackagePath(funcName string) string {
+	vs := strings.Split(funcName, "/")
 	return vs[len(vs)-1]
 }
 
+func formatElapsed(elapsed time.Duration) string {
+	switch {
+	case elapsed >= time.Second:
+		return elapsed.Round(time.Millisecond).String()
+	case elapsed >= time.Millisecond:
+		return elapsed.Round(time.Microsecond).String()
+	default:
+		return elapsed.String()
+	}
+}
+
 func getPrefix(stack int, showTimestamp, showGoroutine bool) string {
+	return formatPrefix(getFuncName(stack), showTimestamp, showGoroutine)
+}
+
+func formatPrefix(funcName string, showTimestamp, showGoroutine bool) string {
+	showTimestamp = getEnvOverride("XTRACEGO_TIMESTAMP", showTimestamp)
+	showGoroutine = getEnvOverride("XTRACEGO_GOROUTINE", showGoroutine)
 	prefix := ""
 	if showTimestamp {
 		prefix += fmt.Sprintf("%20s ", getTimestamp())
@@ -42,57 +266,405 @@ func getPrefix(stack int, showTimestamp, showGoroutine bool) string {
 	if showGoroutine {
 		prefix += fmt.Sprintf("[%2s] ", getGoroutineId())
 	}
-	return prefix + getFuncName(stack) + ": "
+	return prefix + funcName + ": " + strings.Repeat("  ", getCallDepth())
 }
 
-func PrintlnStatement_{{.UniqueString}}(stack int, width int, line, source string, showTimestamp, showGoroutine bool) {
+func PrintlnStatement_{{.UniqueString}}(stack int, width int, line, file string, lineNumber, column int, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_STMT") {
+		return
+	}
+	if traceFormat == "chrome" {
+		return
+	}
+	if traceFormat == "jsonl" {
+		printJSONLine(stack, traceEvent{Kind: "stmt", File: file, Line: lineNumber, Column: column, Source: strings.TrimSpace(line)}, showTimestamp, showGoroutine)
+		return
+	}
 	prefix := getPrefix(stack, showTimestamp, showGoroutine)
+	source := fmt.Sprintf(" %s:%d:%d", file, lineNumber, column)
 	lenPrefix, lenLine, lenSource := len(prefix), len(line), len(source)
 	dots := ""
 	if lenPrefix+lenLine+lenSource < width {
 		dots = strings.Repeat("-", width-lenPrefix-lenLine-lenSource)
 	}
-	_, _ = fmt.Fprintln(os.Stderr, prefix + line + dots + source)
+	_, _ = fmt.Fprintln(traceOutput, prefix + line + dots + source)
 }
 
 func PrintlnVariable_{{.UniqueString}}(stack int, width int, varName string, varValue any, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_VAR") {
+		return
+	}
+	if traceFormat == "chrome" {
+		return
+	}
+	if traceFormat == "jsonl" {
+		printJSONLine(stack, traceEvent{Kind: "var", Name: varName, Value: fmt.Sprintf("%#v", varValue)}, showTimestamp, showGoroutine)
+		return
+	}
 	prefix := getPrefix(stack, showTimestamp, showGoroutine)
 	variable := fmt.Sprintf("[VAR] %s=%#v", varName, varValue)
 	lenPrefix, lenVariable := len(prefix), len(variable)
 	if lenPrefix+lenVariable >= width {
-		_, _ = fmt.Fprintln(os.Stderr, (prefix + variable)[:width-3] + "...")
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable)[:width-3] + "...")
+	} else {
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable))
+	}
+}
+
+// PrintlnTypedVariable_{{.UniqueString}} prints the variable with its dynamic type, such as a variable bound by a type switch.
+func PrintlnTypedVariable_{{.UniqueString}}(stack int, width int, varName string, varValue any, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_VAR") {
+		return
+	}
+	if traceFormat == "chrome" {
+		return
+	}
+	if traceFormat == "jsonl" {
+		printJSONLine(stack, traceEvent{Kind: "var", Name: varName, Value: fmt.Sprintf("%#v", varValue), Type: fmt.Sprintf("%T", varValue)}, showTimestamp, showGoroutine)
+		return
+	}
+	prefix := getPrefix(stack, showTimestamp, showGoroutine)
+	variable := fmt.Sprintf("[VAR] %s=%#v (%T)", varName, varValue, varValue)
+	lenPrefix, lenVariable := len(prefix), len(variable)
+	if lenPrefix+lenVariable >= width {
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable)[:width-3] + "...")
 	} else {
-		_, _ = fmt.Fprintln(os.Stderr, (prefix + variable))
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable))
 	}
 }
 
 func PrintlnReturnVariable_{{.UniqueString}}(stack int, width int, varName string, varValue any, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_VAR") {
+		return
+	}
+	if traceFormat == "chrome" {
+		return
+	}
+	if traceFormat == "jsonl" {
+		printJSONLine(stack, traceEvent{Kind: "var", Name: varName, Value: fmt.Sprintf("%#v", varValue)}, showTimestamp, showGoroutine)
+		return
+	}
 	prefix := getPrefix(stack, showTimestamp, showGoroutine)
 	variable := fmt.Sprintf("[VAR] %s=%#v", varName, varValue)
 	lenPrefix, lenVariable := len(prefix), len(variable)
 	if lenPrefix+lenVariable >= width {
-		_, _ = fmt.Fprintln(os.Stderr, (prefix + variable)[:width-4] + " ...")
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable)[:width-4] + " ...")
+	} else {
+		_, _ = fmt.Fprintln(traceOutput, (prefix + variable))
+	}
+}
+
+// PrintlnReturnError_{{.UniqueString}} prints the message of the returned error if it is not nil.
+// Otherwise, the return variable is printed unless errorsOnly is true.
+func PrintlnReturnError_{{.UniqueString}}(width int, varName string, err error, errorsOnly, showTimestamp, showGoroutine bool) {
+	if isNilError(err) {
+		if !errorsOnly {
+			PrintlnReturnVariable_{{.UniqueString}}(5, width, varName, err, showTimestamp, showGoroutine)
+		}
+		return
+	}
+	funcName, file, line := getTracedFunc(1)
+	caller := traceCaller{funcName: trimPackagePath(funcName), file: file, line: line}
+	printMessage("XTRACEGO_TRACE_ERROR", width, caller, traceEvent{Kind: "error", Name: varName, Value: err.Error(), Type: fmt.Sprintf("%T", err)}, "[ERROR] func "+caller.funcName+": "+err.Error(), showTimestamp, showGoroutine)
+}
+
+// isNilError reports whether the error is nil or holds a nil value of a concrete error type such as (*MyErr)(nil),
+// whose Error method may not be callable.
+func isNilError(err error) bool {
+	if err == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(err); v.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+		return v.IsNil()
+	default:
+		return false
+	}
+}
+
+func printCondition(stack int, width int, expr string, value string, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_COND") {
+		return
+	}
+	if traceFormat == "chrome" {
+		return
+	}
+	if traceFormat == "jsonl" {
+		// The position where the PrintlnCondition or PrintlnType function is called.
+		_, file, line, _ := runtime.Caller(2)
+		printJSONLine(stack, traceEvent{Kind: "cond", File: file, Line: line, Source: expr, Value: value}, showTimestamp, showGoroutine)
+		return
+	}
+	prefix := getPrefix(stack, showTimestamp, showGoroutine)
+	condition := "[COND] " + expr + " => " + value
+	if len(prefix+condition) >= width {
+		_, _ = fmt.Fprintln(traceOutput, (prefix + condition)[:width-4] + " ...")
 	} else {
-		_, _ = fmt.Fprintln(os.Stderr, (prefix + variable))
+		_, _ = fmt.Fprintln(traceOutput, prefix + condition)
+	}
+}
+
+// PrintlnCondition_{{.UniqueString}} prints the value of the condition or the switch tag and returns it as is,
+// so that the expression is evaluated exactly once where it is written.
+// The value is printed as <hidden> if hidden is true.
+func PrintlnCondition_{{.UniqueString}}[T any](stack int, width int, expr string, value T, hidden, showTimestamp, showGoroutine bool) T {
+	printCondition(stack+1, width, expr, formatValue(value, hidden), showTimestamp, showGoroutine)
+	return value
+}
+
+// PrintlnType_{{.UniqueString}} prints the dynamic type of the value switched by a type switch and returns it as is.
+// The dynamic type is printed as <hidden> if hidden is true.
+func PrintlnType_{{.UniqueString}}[T any](stack int, width int, expr string, value T, hidden, showTimestamp, showGoroutine bool) T {
+	dynamicType := "<hidden>"
+	if !hidden {
+		dynamicType = fmt.Sprintf("%T", value)
 	}
+	printCondition(stack+1, width, expr, dynamicType, showTimestamp, showGoroutine)
+	return value
 }
 
-func PrintlnCall_{{.UniqueString}}(width int, signature string, showTimestamp, showGoroutine bool) {
-	prefix := getPrefix(3, showTimestamp, showGoroutine)
-	callStr := prefix + "[CALL] " + signature
-	if len(callStr) >= width {
-		callStr = callStr[:width-4] + " ..."
+func PrintlnCall_{{.UniqueString}}(width int, signature string, showTimestamp, showGoroutine bool) time.Time {
+	if isIndentEnabled() {
+		defer addCallDepth(1)
+	}
+	if !isTraceEnabled("XTRACEGO_TRACE_CALL") {
+		return time.Now()
+	}
+	switch traceFormat {
+	case "chrome":
+		printChromeTraceEvent(getFuncName(2), "B", signature)
+	case "jsonl":
+		printJSONLine(3, traceEvent{Kind: "call", Source: signature}, showTimestamp, showGoroutine)
+	default:
+		prefix := getPrefix(3, showTimestamp, showGoroutine)
+		callStr := prefix + "[CALL] " + signature
+		if len(callStr) >= width {
+			callStr = callStr[:width-4] + " ..."
+		}
+		_, _ = fmt.Fprintln(traceOutput, callStr)
+	}
+	return time.Now()
+}
+
+// PrintlnReturn_{{.UniqueString}} is called by the function deferred at the beginning of the traced function with the value of recover().
+// If the function is panicking, the recovered value is printed as [PANIC] and the function panics again with the value.
+func PrintlnReturn_{{.UniqueString}}(width int, signature string, startTime time.Time, recovered any, showTimestamp, showGoroutine bool) {
+	if isIndentEnabled() {
+		addCallDepth(-1)
+	}
+	printReturn(width, signature, startTime, recovered, showTimestamp, showGoroutine)
+	if recovered != nil {
+		panic(recovered)
 	}
-	_, _ = fmt.Fprintln(os.Stderr, callStr)
 }
 
-func PrintlnReturn_{{.UniqueString}}(width int, signature string, showTimestamp, showGoroutine bool) {
-	prefix := getPrefix(3, showTimestamp, showGoroutine)
-	returnStr := prefix + "[RETURN] " + signature
+func printReturn(width int, signature string, startTime time.Time, recovered any, showTimestamp, showGoroutine bool) {
+	if !isTraceEnabled("XTRACEGO_TRACE_CALL") {
+		return
+	}
+	// The stack consists of printReturn, PrintlnReturn, and the deferred function.
+	funcName, file, line := getTracedFunc(2)
+	funcName = trimPackagePath(funcName)
+	if traceFormat == "chrome" {
+		printChromeTraceEvent(funcName, "E", signature)
+		return
+	}
+	if traceFormat == "jsonl" {
+		event := traceEvent{Kind: "return", Function: funcName, File: file, Line: line, Source: signature, ElapsedNs: time.Since(startTime).Nanoseconds()}
+		if recovered != nil {
+			event.Kind, event.Value = "panic", fmt.Sprint(recovered)
+		}
+		printJSONLine(0, event, showTimestamp, showGoroutine)
+		return
+	}
+	prefix := formatPrefix(funcName, showTimestamp, showGoroutine)
+	returnStr := prefix + "[RETURN] " + signature + " (" + formatElapsed(time.Since(startTime)) + ")"
+	if recovered != nil {
+		returnStr = prefix + "[PANIC] " + signature + ": " + fmt.Sprint(recovered) + " (" + formatElapsed(time.Since(startTime)) + ")"
+	}
 	if len(returnStr) >= width {
 		returnStr = returnStr[:width-4] + " ..."
 	}
-	_, _ = fmt.Fprintln(os.Stderr, returnStr)
+	_, _ = fmt.Fprintln(traceOutput, returnStr)
+}
+
+// PrintlnGo_{{.UniqueString}} is called by the go statement in the parent goroutine and returns the function called in the child goroutine,
+// which prints [GO] with the IDs of the parent and child goroutines, calls the function spawned by the go statement, and prints [GOEXIT].
+func PrintlnGo_{{.UniqueString}}(width int, call string, showTimestamp, showGoroutine bool) func(func()) {
+	if !isMessagePrinted("XTRACEGO_TRACE_CALL") {
+		return func(spawned func()) { spawned() }
+	}
+	parent, caller := getGoroutineId(), getCaller(1)
+	return func(spawned func()) {
+		child := getGoroutineId()
+		printMessage("XTRACEGO_TRACE_CALL", width, caller, traceEvent{Kind: "go", Source: call, Parent: parent, Child: child}, "[GO] parent="+parent+" child="+child+" "+call, showTimestamp, showGoroutine)
+		defer printMessage("XTRACEGO_TRACE_CALL", width, caller, traceEvent{Kind: "goexit", Source: call, Child: child}, "[GOEXIT] "+child, showTimestamp, showGoroutine)
+		spawned()
+	}
+}
+
+// traceCaller is the function and the position where a Println* function is called.
+type traceCaller struct {
+	funcName string
+	file     string
+	line     int
+}
+
+// getCaller returns the caller at the stack, where 0 is the function calling getCaller.
+func getCaller(stack int) traceCaller {
+	pc, file, line, _ := runtime.Caller(stack + 1)
+	return traceCaller{funcName: trimPackagePath(runtime.FuncForPC(pc).Name()), file: file, line: line}
+}
+
+// isMessagePrinted reports whether printMessage prints messages of the trace enabled by the environment variable,
+// so that the callers can skip preparing the messages.
+func isMessagePrinted(env string) bool {
+	return isTraceEnabled(env) && traceFormat != "chrome"
+}
+
+// printMessage prints the event in JSON Lines or the message in text if the trace is enabled by the environment variable.
+func printMessage(env string, width int, caller traceCaller, event traceEvent, message string, showTimestamp, showGoroutine bool) {
+	if !isMessagePrinted(env) {
+		return
+	}
+	if traceFormat == "jsonl" {
+		event.Function, event.File, event.Line = caller.funcName, caller.file, caller.line
+		printJSONLine(0, event, showTimestamp, showGoroutine)
+		return
+	}
+	message = formatPrefix(caller.funcName, showTimestamp, showGoroutine) + message
+	if len(message) >= width {
+		message = message[:width-4] + " ..."
+	}
+	_, _ = fmt.Fprintln(traceOutput, message)
+}
+
+// PrintlnSend_{{.UniqueString}} returns the function which sends the value to the channel printing the channel operation.
+// The element type is inferred only from the channel, so that the value is converted to it as in the original send statement.
+// The value is printed as <hidden> if hidden is true.
+func PrintlnSend_{{.UniqueString}}[T any](width int, channel string, ch chan<- T, hidden, showTimestamp, showGoroutine bool) func(value T) {
+	if !isMessagePrinted("XTRACEGO_TRACE_CHAN") {
+		return func(value T) { ch <- value }
+	}
+	caller := getCaller(1)
+	return func(value T) {
+		printMessage("XTRACEGO_TRACE_CHAN", width, caller, traceEvent{Kind: "chan_wait", Name: channel, Source: "send"}, "[CHAN] waiting on send "+channel, showTimestamp, showGoroutine)
+		ch <- value
+		valueStr := formatValue(value, hidden)
+		printMessage("XTRACEGO_TRACE_CHAN", width, caller, traceEvent{Kind: "send", Name: channel, Value: valueStr}, fmt.Sprintf("[SEND] %s <- %s", channel, valueStr), showTimestamp, showGoroutine)
+	}
+}
+
+// PrintlnRecv_{{.UniqueString}} receives a value from the channel printing the channel operation.
+// The value is printed as <hidden> if hidden is true.
+func PrintlnRecv_{{.UniqueString}}[T any](width int, channel string, ch <-chan T, hidden, showTimestamp, showGoroutine bool) T {
+	if !isMessagePrinted("XTRACEGO_TRACE_CHAN") {
+		return <-ch
+	}
+	value, _ := recvChan(width, getCaller(1), channel, ch, hidden, showTimestamp, showGoroutine)
+	return value
+}
+
+// PrintlnRecvOk_{{.UniqueString}} receives a value and whether the channel is not closed from the channel printing the channel operation.
+// The value is printed as <hidden> if hidden is true.
+func PrintlnRecvOk_{{.UniqueString}}[T any](width int, channel string, ch <-chan T, hidden, showTimestamp, showGoroutine bool) (T, bool) {
+	if !isMessagePrinted("XTRACEGO_TRACE_CHAN") {
+		value, ok := <-ch
+		return value, ok
+	}
+	return recvChan(width, getCaller(1), channel, ch, hidden, showTimestamp, showGoroutine)
+}
+
+func recvChan[T any](width int, caller traceCaller, channel string, ch <-chan T, hidden, showTimestamp, showGoroutine bool) (T, bool) {
+	printMessage("XTRACEGO_TRACE_CHAN", width, caller, traceEvent{Kind: "chan_wait", Name: channel, Source: "recv"}, "[CHAN] waiting on recv "+channel, showTimestamp, showGoroutine)
+	value, ok := <-ch
+	valueStr := formatValue(value, hidden)
+	printMessage("XTRACEGO_TRACE_CHAN", width, caller, traceEvent{Kind: "recv", Name: channel, Value: valueStr, OK: &ok}, fmt.Sprintf("[RECV] <-%s => %s (ok=%t)", channel, valueStr, ok), showTimestamp, showGoroutine)
+	return value, ok
+}
+
+// PrintlnClose_{{.UniqueString}} closes the channel printing the channel operation.
+func PrintlnClose_{{.UniqueString}}[T any](width int, channel string, ch chan<- T, showTimestamp, showGoroutine bool) {
+	close(ch)
+	if !isMessagePrinted("XTRACEGO_TRACE_CHAN") {
+		return
+	}
+	printMessage("XTRACEGO_TRACE_CHAN", width, getCaller(1), traceEvent{Kind: "close", Name: channel}, "[CLOSE] "+channel, showTimestamp, showGoroutine)
+}
+
+// PrintlnExternCall_{{.UniqueString}} returns the function which calls the external function printing the arguments and the results.
+// The returned function has the same type as the external function, so that the arguments are typed and evaluated as in the original call.
+// The arguments and the results are printed as <hidden> if hidden is true.
+func PrintlnExternCall_{{.UniqueString}}[F any](width int, callee string, f F, hidden, showTimestamp, showGoroutine bool) F {
+	if !isMessagePrinted("XTRACEGO_TRACE_EXTERN") {
+		return f
+	}
+	caller := getCaller(1)
+	fv := reflect.ValueOf(f)
+	return reflect.MakeFunc(fv.Type(), func(args []reflect.Value) []reflect.Value {
+		argValues := args
+		if fv.Type().IsVariadic() {
+			variadic := args[len(args)-1]
+			argValues = args[:len(args)-1:len(args)-1]
+			for i := 0; i < variadic.Len(); i++ {
+				argValues = append(argValues, variadic.Index(i))
+			}
+		}
+		argStr := formatValues(argValues, hidden)
+		printMessage("XTRACEGO_TRACE_EXTERN", width, caller, traceEvent{Kind: "extern_call", Name: callee, Value: argStr}, "[EXTERN] "+callee+"("+argStr+")", showTimestamp, showGoroutine)
+		var results []reflect.Value
+		if fv.Type().IsVariadic() {
+			results = fv.CallSlice(args)
+		} else {
+			results = fv.Call(args)
+		}
+		resultStr := formatValues(results, hidden)
+		printMessage("XTRACEGO_TRACE_EXTERN", width, caller, traceEvent{Kind: "extern_return", Name: callee, Value: resultStr}, "[EXTERN] "+callee+" => "+resultStr, showTimestamp, showGoroutine)
+		return results
+	}).Interface().(F)
+}
+
+// formatValues formats the values separated by commas, where non-nil errors are formatted with their messages.
+func formatValues(values []reflect.Value, hidden bool) string {
+	strs := []string{}
+	for _, v := range values {
+		value := v.Interface()
+		if err, ok := value.(error); ok && err != nil && !hidden {
+			strs = append(strs, fmt.Sprintf("error(%q)", err.Error()))
+		} else {
+			strs = append(strs, formatValue(value, hidden))
+		}
+	}
+	return strings.Join(strs, ", ")
+}
+
+// formatValue formats the value as a Go-syntax representation, or as <hidden> if hidden is true.
+func formatValue(value any, hidden bool) string {
+	if hidden {
+		return "<hidden>"
+	}
+	return fmt.Sprintf("%#v", value)
+}
+
+// PrintlnRecover_{{.UniqueString}} prints the value returned by recover() and returns it as is.
+// recover() must be called as the argument so that it is called directly by the deferred function.
+func PrintlnRecover_{{.UniqueString}}(width int, recovered any, showTimestamp, showGoroutine bool) any {
+	if !isTraceEnabled("XTRACEGO_TRACE_CALL") {
+		return recovered
+	}
+	if traceFormat == "chrome" {
+		return recovered
+	}
+	if traceFormat == "jsonl" {
+		printJSONLine(3, traceEvent{Kind: "recover", Value: fmt.Sprint(recovered)}, showTimestamp, showGoroutine)
+		return recovered
+	}
+	recoverStr := getPrefix(3, showTimestamp, showGoroutine) + "[RECOVER] " + fmt.Sprint(recovered)
+	if len(recoverStr) >= width {
+		recoverStr = recoverStr[:width-4] + " ..."
+	}
+	_, _ = fmt.Fprintln(traceOutput, recoverStr)
+	return recovered
 }
 `
 
@@ -101,10 +673,19 @@ var xtraceGoTemplate = template.Must(template.New("xtrace.go.tpl").Parse(xtraceG
 type XtraceGoData struct {
 	PackageName  string
 	UniqueString string
+	TraceFormat  TraceFormat
+	TraceOutput  string
+	Indent       bool
 }
 
-func GetLibraryCode(packageName, uniqueString string, w io.Writer) (err error) {
-	d := XtraceGoData{PackageName: packageName, UniqueString: uniqueString}
+func GetLibraryCode(cfg Config, w io.Writer) (err error) {
+	d := XtraceGoData{
+		PackageName:  cfg.LibraryPackageName(),
+		UniqueString: cfg.UniqueString,
+		TraceFormat:  cfg.TraceFormat,
+		TraceOutput:  cfg.TraceOutput,
+		Indent:       cfg.Indent,
+	}
 	if err := xtraceGoTemplate.Execute(w, d); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
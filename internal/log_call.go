@@ -10,9 +10,11 @@ import (
 )
 
 func (x *Xtrace) newCallLogStmt(signature string) ast.Stmt {
-	// PrintlnCall("[CALL] <signature>")
-	return &ast.ExprStmt{
-		X: &ast.CallExpr{
+	// start_abcdefgh := PrintlnCall("[CALL] <signature>")
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent(x.identStartTime())},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.CallExpr{
 			Fun: ast.NewIdent(x.IdentifierPrintlnCall()),
 			Args: []ast.Expr{
 				&ast.BasicLit{
@@ -26,14 +28,35 @@ func (x *Xtrace) newCallLogStmt(signature string) ast.Stmt {
 				&ast.Ident{Name: x.IdentShowTimestamp()},
 				&ast.Ident{Name: x.IdentShowGoroutine()},
 			},
-		},
+		}},
 	}
 }
 
-func (x *Xtrace) newReturnLogStmt(signature string) ast.Stmt {
-	// PrintlnReturn("[RETURN] <signature>")
-	return &ast.DeferStmt{
-		Call: &ast.CallExpr{
+func (x *Xtrace) newReturnLogStmt(signature string, returnVars []ast.Stmt) ast.Stmt {
+	// defer func() {
+	// 	recovered_abcdefgh := recover()
+	// 	if recovered_abcdefgh == nil {
+	// 		PrintlnReturnVariable("<return_1>", return_1_abcdefgh)
+	// 	}
+	// 	PrintlnReturn("[RETURN] <signature>", start_abcdefgh, recovered_abcdefgh)
+	// }()
+	recovered := "recovered_" + x.UniqueString
+	stmts := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(recovered)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("recover")}},
+		},
+	}
+	if len(returnVars) > 0 {
+		// The return values are not printed if the function is panicking.
+		stmts = append(stmts, &ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ast.NewIdent(recovered), Op: token.EQL, Y: ast.NewIdent("nil")},
+			Body: &ast.BlockStmt{List: returnVars},
+		})
+	}
+	stmts = append(stmts, &ast.ExprStmt{
+		X: &ast.CallExpr{
 			Fun: ast.NewIdent(x.IdentifierPrintlnReturn()),
 			Args: []ast.Expr{
 				&ast.BasicLit{
@@ -44,15 +67,47 @@ func (x *Xtrace) newReturnLogStmt(signature string) ast.Stmt {
 					Kind:  token.STRING,
 					Value: fmt.Sprintf(`%q`, signature),
 				},
+				&ast.Ident{Name: x.identStartTime()},
+				&ast.Ident{Name: recovered},
 				&ast.Ident{Name: x.IdentShowTimestamp()},
 				&ast.Ident{Name: x.IdentShowGoroutine()},
 			},
 		},
+	})
+	return &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{},
+				Body: &ast.BlockStmt{List: stmts},
+			},
+		},
+	}
+}
+
+func (x *Xtrace) newRecoverLogExpr(node *ast.CallExpr) ast.Expr {
+	// PrintlnRecover(recover())
+	return &ast.CallExpr{
+		Fun: ast.NewIdent(x.IdentifierPrintlnRecover()),
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf(`%d`, x.LineWidth),
+			},
+			node,
+			&ast.Ident{Name: x.IdentShowTimestamp()},
+			&ast.Ident{Name: x.IdentShowGoroutine()},
+		},
 	}
 }
 
+// identStartTime returns the name of the variable which holds the time when the function is called.
+func (x *Xtrace) identStartTime() string {
+	return "start_" + x.UniqueString
+}
+
 func (x *Xtrace) logCall(c *astutil.Cursor, info *FuncInfo) {
-	if !x.TraceCall {
+	if !x.TraceCall || x.TraceErrorsOnly {
+		// Only the failing returns are printed by logReturnVariables if TraceErrorsOnly is enabled.
 		return
 	}
 	signature := strings.Join(strings.Fields(x.fragment(info.Signature())), " ")
@@ -60,10 +115,22 @@ func (x *Xtrace) logCall(c *astutil.Cursor, info *FuncInfo) {
 	body.List = append(
 		[]ast.Stmt{
 			x.newCallLogStmt(signature),
-			x.newReturnLogStmt(signature),
+			x.newReturnLogStmt(signature, x.newReturnVariableLogStmts(info)),
 		},
 		body.List...,
 	)
 	c.Replace(body)
 	x.libraryRequired = true
 }
+
+// logRecover wraps the call of recover() so that the recovered value is printed.
+func (x *Xtrace) logRecover(c *astutil.Cursor, node *ast.CallExpr) {
+	if !x.TraceCall {
+		return
+	}
+	if ident, ok := node.Fun.(*ast.Ident); !ok || ident.Name != "recover" || len(node.Args) > 0 {
+		return
+	}
+	c.Replace(x.injectExpr(node, x.newRecoverLogExpr(node)))
+	x.libraryRequired = true
+}
@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// CollectCommOperations returns the send statements and receive expressions which are communications of select statements.
+// They cannot be replaced with function calls.
+func CollectCommOperations(f *ast.File) (commOps map[ast.Node]bool) {
+	commOps = map[ast.Node]bool{}
+	ast.Inspect(f, func(n ast.Node) bool {
+		comm, ok := n.(*ast.CommClause)
+		if !ok {
+			return true
+		}
+		switch stmt := comm.Comm.(type) {
+		case *ast.SendStmt:
+			commOps[stmt] = true
+		case *ast.ExprStmt:
+			commOps[stmt.X] = true
+		case *ast.AssignStmt:
+			commOps[stmt.Rhs[0]] = true
+		}
+		return true
+	})
+	return commOps
+}
+
+func (x *Xtrace) newChanLogExpr(funcName string, channel ast.Expr, args ...ast.Expr) *ast.CallExpr {
+	// PrintlnRecv(120, "ch", ch, false, true, true)
+	return &ast.CallExpr{
+		Fun: ast.NewIdent(funcName),
+		Args: append(append([]ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf(`%d`, x.LineWidth),
+			},
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf(`%q`, x.sourceOf(channel)),
+			},
+			channel,
+		}, args...),
+			&ast.Ident{Name: x.IdentShowTimestamp()},
+			&ast.Ident{Name: x.IdentShowGoroutine()},
+		),
+	}
+}
+
+// logSend replaces the send statement with the function call which sends the value printing the operation.
+func (x *Xtrace) logSend(c *astutil.Cursor, node *ast.SendStmt) {
+	if !x.TraceChan || x.commOps[node] {
+		return
+	}
+	// ch <- v  =>  PrintlnSend(120, "ch", ch, false, true, true)(v)
+	hidden := x.isExprHidden(node.Chan, node.Value)
+	send := x.newChanLogExpr(x.IdentifierPrintlnSend(), node.Chan, ast.NewIdent(strconv.FormatBool(hidden)))
+	send.Fun.(*ast.Ident).NamePos = node.Pos()
+	c.Replace(&ast.ExprStmt{X: &ast.CallExpr{
+		Fun:    send,
+		Args:   []ast.Expr{node.Value},
+		Rparen: node.End() - 1,
+	}})
+	x.libraryRequired = true
+}
+
+// logRecv replaces the receive expression with the function call which receives a value printing the operation.
+func (x *Xtrace) logRecv(c *astutil.Cursor, node *ast.UnaryExpr) {
+	if !x.TraceChan || node.Op != token.ARROW || x.commOps[node] {
+		return
+	}
+	// The received value is hidden if it is assigned to a hidden variable.
+	hidden := ast.NewIdent(strconv.FormatBool(x.isExprHidden(append([]ast.Expr{node.X}, assignedExprs(c.Parent())...)...)))
+	if isCommaOk(c.Parent()) {
+		// v, ok := <-ch  =>  v, ok := PrintlnRecvOk(120, "ch", ch, false, true, true)
+		c.Replace(x.injectExpr(node, x.newChanLogExpr(x.IdentifierPrintlnRecvOk(), node.X, hidden)))
+	} else {
+		// <-ch  =>  PrintlnRecv(120, "ch", ch, false, true, true)
+		c.Replace(x.injectExpr(node, x.newChanLogExpr(x.IdentifierPrintlnRecv(), node.X, hidden)))
+	}
+	x.libraryRequired = true
+}
+
+// isCommaOk reports whether the parent assigns the received value and whether the channel is not closed to two operands,
+// such as v, ok := <-ch and var v, ok = <-ch.
+func isCommaOk(parent ast.Node) bool {
+	switch parent := parent.(type) {
+	case *ast.AssignStmt:
+		return len(parent.Lhs) == 2 && len(parent.Rhs) == 1
+	case *ast.ValueSpec:
+		return len(parent.Names) == 2 && len(parent.Values) == 1
+	default:
+		return false
+	}
+}
+
+// CollectChanRanges returns the range statements iterating over the values received from channels.
+func CollectChanRanges(f *ast.File, info *types.Info) (chanRanges map[*ast.RangeStmt]bool) {
+	chanRanges = map[*ast.RangeStmt]bool{}
+	if info == nil {
+		return chanRanges
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		if node, ok := n.(*ast.RangeStmt); ok {
+			if t := info.TypeOf(node.X); t != nil {
+				_, chanRanges[node] = t.Underlying().(*types.Chan)
+			}
+		}
+		return true
+	})
+	return chanRanges
+}
+
+// logRangeRecv replaces the range statement over the channel with the for statement which receives the values printing the operations.
+// The channel is evaluated once before the loop as in the original range statement.
+func (x *Xtrace) logRangeRecv(c *astutil.Cursor, node *ast.RangeStmt) {
+	if !x.TraceChan || !x.chanRanges[node] {
+		return
+	}
+	// for v := range ch { ... }
+	//   =>
+	// for tmp_1 := ch; ; {
+	//   v, tmp_2 := PrintlnRecvOk(120, "ch", tmp_1, false, true, true)
+	//   if !tmp_2 { break }
+	//   ...
+	// }
+	hidden := ast.NewIdent(strconv.FormatBool(x.isExprHidden(node.X, node.Key)))
+	channel, ok := x.newTempName(), x.newTempName()
+	value := node.Key
+	if value == nil {
+		value = ast.NewIdent("_")
+	}
+	var assignValue []ast.Stmt
+	if node.Tok == token.ASSIGN {
+		// for v = range ch  =>  tmp_3, tmp_2 := PrintlnRecvOk(...); ...; v = tmp_3
+		temp := ast.NewIdent(x.newTempName())
+		assignValue = []ast.Stmt{&ast.AssignStmt{Lhs: []ast.Expr{value}, Tok: token.ASSIGN, Rhs: []ast.Expr{temp}}}
+		value = temp
+	}
+	recv := x.newChanLogExpr(x.IdentifierPrintlnRecvOk(), x.injectExpr(node.X, ast.NewIdent(channel)), hidden)
+	node.Body.List = append(append([]ast.Stmt{
+		&ast.AssignStmt{Lhs: []ast.Expr{value, ast.NewIdent(ok)}, Tok: token.DEFINE, Rhs: []ast.Expr{recv}},
+		&ast.IfStmt{
+			Cond: &ast.UnaryExpr{Op: token.NOT, X: ast.NewIdent(ok)},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.BranchStmt{Tok: token.BREAK}}},
+		},
+	}, assignValue...), node.Body.List...)
+	c.Replace(&ast.ForStmt{
+		For:  node.For,
+		Init: &ast.AssignStmt{Lhs: []ast.Expr{ast.NewIdent(channel)}, Tok: token.DEFINE, Rhs: []ast.Expr{node.X}},
+		Body: node.Body,
+	})
+	x.libraryRequired = true
+}
+
+// logClose replaces the call of close with the function call which closes the channel printing the operation.
+func (x *Xtrace) logClose(c *astutil.Cursor, node *ast.CallExpr) {
+	if !x.TraceChan {
+		return
+	}
+	if ident, ok := node.Fun.(*ast.Ident); !ok || ident.Name != "close" || len(node.Args) != 1 {
+		return
+	}
+	// close(ch)  =>  PrintlnClose(120, "ch", ch, true, true)
+	c.Replace(x.injectExpr(node, x.newChanLogExpr(x.IdentifierPrintlnClose(), node.Args[0])))
+	x.libraryRequired = true
+}
@@ -0,0 +1,87 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+func (x *Xtrace) newConditionLogExpr(funcName string, label string, value ast.Expr) ast.Expr {
+	// PrintlnCondition(3, 120, "a == 1", a == 1, false, true, true)
+	// The value is hidden if it refers to hidden variables.
+	hidden := x.isExprHidden(value)
+	return &ast.CallExpr{
+		Fun: ast.NewIdent(funcName),
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf(`%d`, 3),
+			},
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf(`%d`, x.LineWidth),
+			},
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf("%q", label),
+			},
+			value,
+			ast.NewIdent(strconv.FormatBool(hidden)),
+			&ast.Ident{Name: x.IdentShowTimestamp()},
+			&ast.Ident{Name: x.IdentShowGoroutine()},
+		},
+	}
+}
+
+// logIfCondition wraps the condition of the if statement so that its value is printed when it is evaluated.
+// Each condition in an else-if chain is wrapped by itself and is evaluated only if the previous conditions are false.
+func (x *Xtrace) logIfCondition(c *astutil.Cursor, node *ast.IfStmt) {
+	if !x.TraceCond {
+		return
+	}
+
+	label := x.sourceOf(node.Cond)
+	node.Cond = x.injectExpr(node.Cond, x.newConditionLogExpr(x.IdentifierPrintlnCondition(), label, node.Cond))
+	c.Replace(node)
+	x.libraryRequired = true
+}
+
+// logSwitchTag wraps the tag of the switch statement so that its value is printed when it is evaluated.
+func (x *Xtrace) logSwitchTag(c *astutil.Cursor, node *ast.SwitchStmt) {
+	if !x.TraceCond || node.Tag == nil {
+		return
+	}
+
+	label := x.sourceOf(node.Tag)
+	node.Tag = x.injectExpr(node.Tag, x.newConditionLogExpr(x.IdentifierPrintlnCondition(), label, node.Tag))
+	c.Replace(node)
+	x.libraryRequired = true
+}
+
+// logTypeSwitchType wraps the operand of the type switch guard so that its dynamic type is printed when it is evaluated.
+func (x *Xtrace) logTypeSwitchType(c *astutil.Cursor, node *ast.TypeSwitchStmt) {
+	if !x.TraceCond {
+		return
+	}
+
+	var guard ast.Expr
+	switch assign := node.Assign.(type) {
+	case *ast.ExprStmt:
+		// switch x.(type)
+		guard = assign.X
+	case *ast.AssignStmt:
+		// switch v := x.(type)
+		guard = assign.Rhs[0]
+	}
+	assert, ok := guard.(*ast.TypeAssertExpr)
+	if !ok {
+		return
+	}
+	label := x.sourceOf(assert)
+	assert.X = x.injectExpr(assert.X, x.newConditionLogExpr(x.IdentifierPrintlnType(), label, assert.X))
+	c.Replace(node)
+	x.libraryRequired = true
+}
@@ -0,0 +1,101 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// untracedExternPackages are the packages whose functions are not wrapped,
+// because they are mostly generic, they cannot be used as function values, or they depend on their callers.
+// For example, log.Println with log.Lshortfile prints the position of its caller found by runtime.Caller,
+// which would be in the reflect package if it were called by the wrapper.
+var untracedExternPackages = regexp.MustCompile(`^(C|unsafe|slices|maps|cmp|iter|runtime(/.*)?|log(/slog)?|testing(/.*)?)$`)
+
+// CollectExternPackages returns the names of the packages outside the module which are imported by the file.
+// The name of a package imported without an explicit name is guessed from its import path.
+func CollectExternPackages(f *ast.File, moduleName string) (externPackages map[string]bool) {
+	externPackages = map[string]bool{}
+	for _, spec := range f.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || untracedExternPackages.MatchString(importPath) {
+			continue
+		}
+		if moduleName != "" && (importPath == moduleName || strings.HasPrefix(importPath, moduleName+"/")) {
+			continue
+		}
+		name := guessPackageName(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if name == "_" || name == "." {
+			continue
+		}
+		externPackages[name] = true
+	}
+	return externPackages
+}
+
+var majorVersionRegexp = regexp.MustCompile(`^v[0-9]+$`)
+
+// guessPackageName guesses the package name from the import path such as gopkg.in/yaml.v3, github.com/mattn/go-isatty, and example.com/foo/v2.
+func guessPackageName(importPath string) string {
+	name := path.Base(importPath)
+	if majorVersionRegexp.MatchString(name) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.TrimPrefix(name, "go-")
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+func (x *Xtrace) newExternCallLogExpr(fun *ast.SelectorExpr, hidden bool) ast.Expr {
+	// PrintlnExternCall(120, "os.ReadFile", os.ReadFile, false, true, true)
+	return &ast.CallExpr{
+		Fun: ast.NewIdent(x.IdentifierPrintlnExternCall()),
+		Args: []ast.Expr{
+			&ast.BasicLit{
+				Kind:  token.INT,
+				Value: fmt.Sprintf(`%d`, x.LineWidth),
+			},
+			&ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf(`%q`, x.sourceOf(fun)),
+			},
+			fun,
+			ast.NewIdent(strconv.FormatBool(hidden)),
+			&ast.Ident{Name: x.IdentShowTimestamp()},
+			&ast.Ident{Name: x.IdentShowGoroutine()},
+		},
+	}
+}
+
+// logExternCall wraps the function of the call of a package outside the module so that the arguments and the results are printed:
+//
+//	os.ReadFile(path)  =>  PrintlnExternCall("os.ReadFile", os.ReadFile)(path)
+func (x *Xtrace) logExternCall(c *astutil.Cursor, node *ast.CallExpr) {
+	if !x.TraceExternCalls {
+		return
+	}
+	fun, ok := node.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	if pkg, ok := fun.X.(*ast.Ident); !ok || !x.externPackages[pkg.Name] || !x.isPackageName(pkg) {
+		return
+	}
+	// Type conversions such as time.Duration(n) and generic functions cannot be wrapped.
+	if !x.isFunc(fun.Sel) || x.isGenericFunc(fun.Sel) {
+		return
+	}
+	// The arguments and the results are hidden if they refer to or are assigned to hidden variables.
+	hidden := x.isExprHidden(append(append([]ast.Expr{}, node.Args...), assignedExprs(c.Parent())...)...)
+	node.Fun = x.injectExpr(fun, x.newExternCallLogExpr(fun, hidden))
+	x.libraryRequired = true
+}
@@ -0,0 +1,150 @@
+package internal
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// logGo wraps the call spawned by the go statement so that the spawn and the exit of the goroutine are printed:
+//
+//	tmp_1_abcdefgh := x
+//	go PrintlnGo("f(x)")(func() { f(tmp_1_abcdefgh) })
+//
+// The function value including the receiver of a method and the arguments are evaluated in the parent goroutine as in the original go statement.
+// Constants are left as they are so that untyped constants are converted to the types of the parameters,
+// untyped non-constant values such as 1<<s are assigned to temporary variables declared with the types of the parameters,
+// and the results of a call passed as the only argument such as f(g()) are assigned to as many temporary variables.
+func (x *Xtrace) logGo(c *astutil.Cursor, node *ast.GoStmt) {
+	if !x.TraceCall {
+		return
+	}
+	switch c.Parent().(type) {
+	case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause:
+	default:
+		// The temporary variables cannot be inserted before the go statement.
+		return
+	}
+
+	label := strings.Join(strings.Fields(x.sourceOf(node.Call)), " ")
+	call := node.Call
+	switch fun := call.Fun.(type) {
+	case *ast.Ident, *ast.FuncLit:
+	case *ast.SelectorExpr:
+		// The method value binds the receiver, while the qualified function of a package is left as it is.
+		if ident, ok := fun.X.(*ast.Ident); !ok || !x.isPackageName(ident) {
+			call.Fun = x.hoistValue(c, fun)
+		}
+	default:
+		call.Fun = x.hoistValue(c, fun)
+	}
+	if len(call.Args) == 1 && x.resultCount(call.Args[0]) > 1 {
+		call.Args = x.hoistValues(c, call.Args[0], x.resultCount(call.Args[0]))
+	} else {
+		for i, arg := range call.Args {
+			switch {
+			case isConstantLiteral(arg) || x.isConstant(arg):
+			case x.isUntyped(arg):
+				// The untyped value is left as it is if its type cannot be written in the file.
+				if typeExpr, ok := x.typeExprOf(arg); ok {
+					call.Args[i] = x.hoistTypedValue(c, arg, typeExpr)
+				}
+			default:
+				call.Args[i] = x.hoistValue(c, arg)
+			}
+		}
+	}
+
+	node.Call = &ast.CallExpr{
+		Fun: &ast.CallExpr{
+			Fun: ast.NewIdent(x.IdentifierPrintlnGo()),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, x.LineWidth),
+				},
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf(`%q`, label),
+				},
+				&ast.Ident{Name: x.IdentShowTimestamp()},
+				&ast.Ident{Name: x.IdentShowGoroutine()},
+			},
+		},
+		Args: []ast.Expr{&ast.FuncLit{
+			Type: &ast.FuncType{},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{X: call}}},
+		}},
+		// The go statement keeps its range.
+		Rparen: call.Rparen,
+	}
+	c.Replace(node)
+	x.libraryRequired = true
+}
+
+// hoistValue assigns the value of the expression to a temporary variable before the statement at the cursor
+// and returns the identifier of the temporary variable.
+func (x *Xtrace) hoistValue(c *astutil.Cursor, expr ast.Expr) ast.Expr {
+	// tmp_1_abcdefgh := expr
+	temp := x.newTempName()
+	c.InsertBefore(&ast.AssignStmt{
+		Lhs: []ast.Expr{ast.NewIdent(temp)},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{expr},
+	})
+	return x.injectExpr(expr, ast.NewIdent(temp))
+}
+
+// hoistTypedValue assigns the value of the expression to a temporary variable of the type before the statement at the cursor
+// and returns the identifier of the temporary variable.
+func (x *Xtrace) hoistTypedValue(c *astutil.Cursor, expr ast.Expr, typeExpr ast.Expr) ast.Expr {
+	// var tmp_1_abcdefgh int64 = expr
+	temp := x.newTempName()
+	c.InsertBefore(&ast.DeclStmt{Decl: &ast.GenDecl{
+		Tok: token.VAR,
+		Specs: []ast.Spec{&ast.ValueSpec{
+			Names:  []*ast.Ident{ast.NewIdent(temp)},
+			Type:   typeExpr,
+			Values: []ast.Expr{expr},
+		}},
+	}})
+	return x.injectExpr(expr, ast.NewIdent(temp))
+}
+
+// hoistValues assigns the n results of the call to temporary variables before the statement at the cursor
+// and returns the identifiers of the temporary variables.
+func (x *Xtrace) hoistValues(c *astutil.Cursor, call ast.Expr, n int) []ast.Expr {
+	// tmp_1_abcdefgh, tmp_2_abcdefgh := f()
+	temps := []ast.Expr{}
+	for i := 0; i < n; i++ {
+		temps = append(temps, ast.NewIdent(x.newTempName()))
+	}
+	c.InsertBefore(&ast.AssignStmt{
+		Lhs: temps,
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{call},
+	})
+	return temps
+}
+
+// isConstantLiteral returns whether the expression consists of only literals,
+// which can be evaluated anywhere and must not be typed by a temporary variable.
+func isConstantLiteral(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		return expr.Name == "nil" || expr.Name == "true" || expr.Name == "false"
+	case *ast.ParenExpr:
+		return isConstantLiteral(expr.X)
+	case *ast.UnaryExpr:
+		return expr.Op != token.ARROW && expr.Op != token.AND && isConstantLiteral(expr.X)
+	case *ast.BinaryExpr:
+		return isConstantLiteral(expr.X) && isConstantLiteral(expr.Y)
+	default:
+		return false
+	}
+}
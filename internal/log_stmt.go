@@ -11,7 +11,7 @@ import (
 )
 
 func (x *Xtrace) newStatementLogStmt(stack int, pos token.Position, fragment string) ast.Stmt {
-	// PrintlnStatement(`if a == 1 { ...... path/to/source.go:123:45`)
+	// PrintlnStatement(`if a == 1 { `, "path/to/source.go", 123, 45)
 	line := strings.ReplaceAll(fragment, "\t", "    ") + " "
 	return &ast.ExprStmt{
 		X: &ast.CallExpr{
@@ -31,7 +31,15 @@ func (x *Xtrace) newStatementLogStmt(stack int, pos token.Position, fragment str
 				},
 				&ast.BasicLit{
 					Kind:  token.STRING,
-					Value: fmt.Sprintf("%q", fmt.Sprintf(" %s:%d:%d", pos.Filename, pos.Line, pos.Column)),
+					Value: fmt.Sprintf("%q", pos.Filename),
+				},
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, pos.Line),
+				},
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, pos.Column),
 				},
 				&ast.Ident{Name: x.IdentShowTimestamp()},
 				&ast.Ident{Name: x.IdentShowGoroutine()},
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"go/ast"
 	"go/token"
-	"slices"
 
 	"github.com/samber/lo/mutable"
 	"golang.org/x/tools/go/ast/astutil"
@@ -14,94 +13,113 @@ func (x *Xtrace) newVariableLogStmt(stack int, name string, shadowed bool) ast.S
 	// PrintlnVariable("VarName", VarName))
 	// PrintlnVariable("VarName", "<shadowed>"))
 	if shadowed {
-		return &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun: ast.NewIdent(x.IdentifierPrintlnVariable()),
-				Args: []ast.Expr{
-					&ast.BasicLit{
-						Kind:  token.INT,
-						Value: fmt.Sprintf(`%d`, stack),
-					},
-					&ast.BasicLit{
-						Kind:  token.INT,
-						Value: fmt.Sprintf(`%d`, x.LineWidth),
-					},
-					&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: fmt.Sprintf("%q", name),
-					},
-					&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: `"<shadowed>"`,
-					},
-					&ast.Ident{Name: x.IdentShowTimestamp()},
-					&ast.Ident{Name: x.IdentShowGoroutine()},
+		return x.newValueLogStmt(stack, name, &ast.BasicLit{Kind: token.STRING, Value: `"<shadowed>"`})
+	}
+	return x.newValueLogStmt(stack, name, &ast.Ident{Name: name})
+}
+
+func (x *Xtrace) newValueLogStmt(stack int, label string, value ast.Expr) ast.Stmt {
+	// PrintlnVariable("cfg.Port", cfg.Port))
+	// PrintlnVariable("cfg.Port", "<hidden>"))
+	if x.isVarHidden(label) {
+		value = &ast.BasicLit{Kind: token.STRING, Value: `"<hidden>"`}
+	}
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent(x.IdentifierPrintlnVariable()),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, stack),
 				},
-			},
-		}
-	} else {
-		return &ast.ExprStmt{
-			X: &ast.CallExpr{
-				Fun: ast.NewIdent(x.IdentifierPrintlnVariable()),
-				Args: []ast.Expr{
-					&ast.BasicLit{
-						Kind:  token.INT,
-						Value: fmt.Sprintf(`%d`, stack),
-					},
-					&ast.BasicLit{
-						Kind:  token.INT,
-						Value: fmt.Sprintf(`%d`, x.LineWidth),
-					},
-					&ast.BasicLit{
-						Kind:  token.STRING,
-						Value: fmt.Sprintf("%q", name),
-					},
-					&ast.Ident{Name: name},
-					&ast.Ident{Name: x.IdentShowTimestamp()},
-					&ast.Ident{Name: x.IdentShowGoroutine()},
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, x.LineWidth),
 				},
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", label),
+				},
+				value,
+				&ast.Ident{Name: x.IdentShowTimestamp()},
+				&ast.Ident{Name: x.IdentShowGoroutine()},
 			},
-		}
+		},
+	}
+}
+
+func (x *Xtrace) newTypedVariableLogStmt(stack int, name string) ast.Stmt {
+	// PrintlnTypedVariable("VarName", VarName))
+	stmt := x.newVariableLogStmt(stack, name, false)
+	if !x.isVarHidden(name) {
+		stmt.(*ast.ExprStmt).X.(*ast.CallExpr).Fun = ast.NewIdent(x.IdentifierPrintlnTypedVariable())
 	}
+	return stmt
 }
+
 func (x *Xtrace) newReturnVariableLogStmt(number int, name string) ast.Stmt {
-	// defer func () { PrintlnVariable("VarName", VarName)) }
-	// defer func () { PrintlnVariable("<return_1>", return_1_abcdefg)) }
+	// PrintlnVariable("VarName", VarName))
+	// PrintlnVariable("<return_1>", return_1_abcdefg))
 	varName := name
 	if name == "" {
 		name = fmt.Sprintf("<return_%d>", number)
 		varName = fmt.Sprintf("return_%d_%s", number, x.UniqueString)
 	}
-	return &ast.DeferStmt{
-		Call: &ast.CallExpr{
-			Fun: &ast.FuncLit{
-				Type: &ast.FuncType{},
-				Body: &ast.BlockStmt{
-					List: []ast.Stmt{
-						&ast.ExprStmt{
-							X: &ast.CallExpr{
-								Fun: ast.NewIdent(x.IdentifierPrintlnReturnVariable()),
-								Args: []ast.Expr{
-									&ast.BasicLit{
-										Kind:  token.INT,
-										Value: fmt.Sprintf(`%d`, 4),
-									},
-									&ast.BasicLit{
-										Kind:  token.INT,
-										Value: fmt.Sprintf(`%d`, x.LineWidth),
-									},
-									&ast.BasicLit{
-										Kind:  token.STRING,
-										Value: fmt.Sprintf("%q", name),
-									},
-									&ast.Ident{Name: varName},
-									&ast.Ident{Name: x.IdentShowTimestamp()},
-									&ast.Ident{Name: x.IdentShowGoroutine()},
-								},
-							},
-						},
-					},
+	var value ast.Expr = &ast.Ident{Name: varName}
+	if x.isVarHidden(name) {
+		value = &ast.BasicLit{Kind: token.STRING, Value: `"<hidden>"`}
+	}
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent(x.IdentifierPrintlnReturnVariable()),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, 4),
+				},
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, x.LineWidth),
+				},
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", name),
+				},
+				value,
+				&ast.Ident{Name: x.IdentShowTimestamp()},
+				&ast.Ident{Name: x.IdentShowGoroutine()},
+			},
+		},
+	}
+}
+
+func (x *Xtrace) newReturnErrorLogStmt(number int, name string) ast.Stmt {
+	// PrintlnReturnError("err", err, false)
+	// PrintlnReturnError("<return_2>", return_2_abcdefg, false)
+	varName := name
+	if name == "" {
+		name = fmt.Sprintf("<return_%d>", number)
+		varName = fmt.Sprintf("return_%d_%s", number, x.UniqueString)
+	}
+	if x.isVarHidden(name) {
+		return x.newReturnVariableLogStmt(number, varName)
+	}
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: ast.NewIdent(x.IdentifierPrintlnReturnError()),
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.INT,
+					Value: fmt.Sprintf(`%d`, x.LineWidth),
 				},
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf("%q", name),
+				},
+				&ast.Ident{Name: varName},
+				&ast.Ident{Name: fmt.Sprintf("%t", x.TraceErrorsOnly)},
+				&ast.Ident{Name: x.IdentShowTimestamp()},
+				&ast.Ident{Name: x.IdentShowGoroutine()},
 			},
 		},
 	}
@@ -196,12 +214,12 @@ func (x *Xtrace) logLocalAssignment(c *astutil.Cursor, node *ast.AssignStmt) {
 	}
 
 	stmts := []ast.Stmt{}
-	for _, lexpr := range node.Lhs {
-		ident, ok := lexpr.(*ast.Ident)
-		if !ok || ident.Name == "_" {
-			continue
+	for i, lexpr := range node.Lhs {
+		var stmt ast.Stmt
+		node.Lhs[i], stmt = x.newAssignedLogStmt(c, lexpr)
+		if stmt != nil {
+			stmts = append(stmts, stmt)
 		}
-		stmts = append(stmts, x.newVariableLogStmt(3, ident.Name, false))
 	}
 	mutable.Reverse(stmts)
 	for _, decl := range stmts {
@@ -211,6 +229,39 @@ func (x *Xtrace) logLocalAssignment(c *astutil.Cursor, node *ast.AssignStmt) {
 
 }
 
+func (x *Xtrace) logLocalIncDec(c *astutil.Cursor, node *ast.IncDecStmt) {
+	if !x.TraceVar {
+		return
+	}
+
+	var stmt ast.Stmt
+	node.X, stmt = x.newAssignedLogStmt(c, node.X)
+	if stmt != nil {
+		c.InsertAfter(stmt)
+		x.libraryRequired = true
+	}
+}
+
+// newAssignedLogStmt returns the statement which logs the value of the assigned expression after the assignment.
+// The side effects and the indices in the expression are hoisted before the assignment and the rewritten expression is returned,
+// so that the logged expression refers to the assigned element even if the assignment changes the indices such as arr[i], i = 99, 2.
+func (x *Xtrace) newAssignedLogStmt(c *astutil.Cursor, lexpr ast.Expr) (ast.Expr, ast.Stmt) {
+	switch lexpr := lexpr.(type) {
+	case *ast.Ident:
+		if lexpr.Name == "_" {
+			return lexpr, nil
+		}
+		return lexpr, x.newVariableLogStmt(3, lexpr.Name, false)
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr, *ast.StarExpr, *ast.ParenExpr:
+		// cfg.Port, m[key], arr[i], *p
+		label := x.sourceOf(lexpr)
+		hoisted := x.hoistIndices(c, x.hoistSideEffects(c, lexpr))
+		return hoisted, x.newValueLogStmt(3, label, cloneNode(hoisted))
+	default:
+		return lexpr, nil
+	}
+}
+
 func (x *Xtrace) logForVariables(c *astutil.Cursor, info *ForInfo) {
 	if !x.TraceVar {
 		return
@@ -253,7 +304,37 @@ func (x *Xtrace) logIfVariables(c *astutil.Cursor, info *IfElseInfo) {
 	}
 }
 
+func (x *Xtrace) logCaseVariables(c *astutil.Cursor, info *CaseInfo) {
+	if !x.TraceVar {
+		return
+	}
+
+	stmts := []ast.Stmt{}
+	for _, ident := range info.Variables() {
+		if info.TypeSwitch != nil {
+			stmts = append(stmts, x.newTypedVariableLogStmt(3, ident.Name))
+		} else {
+			stmts = append(stmts, x.newVariableLogStmt(3, ident.Name, false))
+		}
+	}
+	if len(stmts) == 0 {
+		return
+	}
+	if info.Case != nil {
+		info.Case.Body = append(stmts, info.Case.Body...)
+		c.Replace(info.Case)
+	}
+	if info.Comm != nil {
+		info.Comm.Body = append(stmts, info.Comm.Body...)
+		c.Replace(info.Comm)
+	}
+	x.libraryRequired = true
+}
+
 func (x *Xtrace) logCallVariables(c *astutil.Cursor, info *FuncInfo) {
+	if x.TraceErrorsOnly {
+		return
+	}
 	fields := []*ast.Field{}
 	if info.FuncDecl != nil {
 		fields = info.FuncDecl.Type.Params.List
@@ -277,7 +358,10 @@ func (x *Xtrace) logCallVariables(c *astutil.Cursor, info *FuncInfo) {
 	x.libraryRequired = true
 }
 
-func (x *Xtrace) logReturnVariables(c *astutil.Cursor, info *FuncInfo) {
+// newReturnVariableLogStmts returns the statements which print the return variables in a deferred function.
+// The return variables of error types are printed as errors if they are not nil.
+// If TraceErrorsOnly is enabled, only the return variables of error types are printed.
+func (x *Xtrace) newReturnVariableLogStmts(info *FuncInfo) []ast.Stmt {
 	fields := []*ast.Field{}
 	if info.FuncDecl != nil && info.FuncDecl.Type.Results != nil {
 		fields = info.FuncDecl.Type.Results.List
@@ -289,9 +373,16 @@ func (x *Xtrace) logReturnVariables(c *astutil.Cursor, info *FuncInfo) {
 	params := []ast.Stmt{}
 	count := 0
 	for _, param := range fields {
+		newLogStmt := x.newReturnVariableLogStmt
+		if x.isErrorType(param.Type) {
+			newLogStmt = x.newReturnErrorLogStmt
+		} else if x.TraceErrorsOnly {
+			count += max(len(param.Names), 1)
+			continue
+		}
 		if len(param.Names) == 0 {
 			count++
-			params = append(params, x.newReturnVariableLogStmt(count, ""))
+			params = append(params, newLogStmt(count, ""))
 		} else {
 			for _, name := range param.Names {
 				varName := name.Name
@@ -299,13 +390,66 @@ func (x *Xtrace) logReturnVariables(c *astutil.Cursor, info *FuncInfo) {
 					varName = ""
 				}
 				count++
-				params = append(params, x.newReturnVariableLogStmt(count, varName))
+				params = append(params, newLogStmt(count, varName))
 			}
 		}
 	}
+	return params
+}
+
+func (x *Xtrace) logReturnVariables(c *astutil.Cursor, info *FuncInfo) {
+	if x.TraceCall && !x.TraceErrorsOnly {
+		// The return variables are printed in the function deferred by logCall unless the function is panicking.
+		return
+	}
 
-	slices.Reverse(params)
-	info.Body.List = append(params, info.Body.List...)
+	// defer func() {
+	// 	recovered_abcdefgh := recover()
+	// 	if recovered_abcdefgh != nil {
+	// 		panic(recovered_abcdefgh)
+	// 	}
+	// 	PrintlnReturnError("<return_1>", return_1_abcdefgh)
+	// }()
+	// The return variables are not printed if the function is panicking, and the panic continues.
+	returnVars := x.newReturnVariableLogStmts(info)
+	if len(returnVars) == 0 {
+		return
+	}
+	recovered := "recovered_" + x.UniqueString
+	stmts := append([]ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(recovered)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.CallExpr{Fun: ast.NewIdent("recover")}},
+		},
+		&ast.IfStmt{
+			Cond: &ast.BinaryExpr{X: ast.NewIdent(recovered), Op: token.NEQ, Y: ast.NewIdent("nil")},
+			Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ExprStmt{
+				X: &ast.CallExpr{Fun: ast.NewIdent("panic"), Args: []ast.Expr{ast.NewIdent(recovered)}},
+			}}},
+		},
+	}, returnVars...)
+	info.Body.List = append([]ast.Stmt{&ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.FuncLit{
+				Type: &ast.FuncType{},
+				Body: &ast.BlockStmt{List: stmts},
+			},
+		},
+	}}, info.Body.List...)
 	c.Replace(info.Body)
 	x.libraryRequired = true
 }
+
+// hoistIndices replaces the non-constant indices in the expression with temporary variables assigned before the statement at the cursor.
+func (x *Xtrace) hoistIndices(c *astutil.Cursor, expr ast.Expr) ast.Expr {
+	return astutil.Apply(expr, nil, func(c2 *astutil.Cursor) bool {
+		if node, ok := c2.Node().(*ast.IndexExpr); ok {
+			// The temporary variables of the hoisted side effects are not assigned again.
+			if _, hoisted := x.sources[node.Index]; !hoisted && !isConstantLiteral(node.Index) && !x.isConstant(node.Index) {
+				node.Index = x.hoistValue(c, node.Index)
+			}
+		}
+		return true
+	}).(ast.Expr)
+}
@@ -2,6 +2,11 @@ package internal
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -48,6 +53,27 @@ type ResolvedPackage struct {
 	PackageDir  string
 	GoModFile   string
 	Module      string
+	// GoWorkFile is the path to go.work if the package is resolved in workspace mode.
+	GoWorkFile string
+	// LocalModules holds the modules used in the workspace and the modules replaced with local directories.
+	LocalModules []LocalModule
+	// RootDir is the directory containing the main module, the workspace, and the local modules.
+	RootDir string
+	// ImportPaths holds the import paths of the packages by their source files.
+	// The source files specified as command-line arguments have the import path of their directory in the main module.
+	ImportPaths map[string]string
+	// TypedFiles holds the parsed and type-checked source files by their paths.
+	TypedFiles map[string]TypedFile
+	// DependencyModules holds the modules in the module cache or the vendor directory which provide the dependencies.
+	// The source files of their packages also have import paths in ImportPaths.
+	DependencyModules []DependencyModule
+}
+
+// TypedFile is a source file parsed and type-checked together with the other files of its package.
+type TypedFile struct {
+	Fset *token.FileSet
+	File *ast.File
+	Info *types.Info
 }
 
 func ResolvePackage(packageArg string) (resolved ResolvedPackage, err error) {
@@ -55,7 +81,7 @@ func ResolvePackage(packageArg string) (resolved ResolvedPackage, err error) {
 		return ResolvedPackage{}, fmt.Errorf("no package specified")
 	}
 	c := packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedEmbedPatterns | packages.NeedDeps | packages.NeedImports | packages.NeedModule,
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedEmbedFiles | packages.NeedDeps | packages.NeedImports | packages.NeedModule,
 	}
 	pkgs, err := packages.Load(&c, strings.Split(packageArg, ",")...)
 	if err != nil {
@@ -65,8 +91,15 @@ func ResolvePackage(packageArg string) (resolved ResolvedPackage, err error) {
 		sourceFileSet          = map[string]bool{}
 		isCommandLineArguments bool
 		mainPackageDir         string
-		goModFile              string
-		moduleName             string
+		mainModule             *packages.Module
+		mainModules            []*packages.Module
+		packagePaths           []string
+		// moduleFileSets holds the source files of the packages in the main module and the local modules by the module directories.
+		moduleFileSets = map[string]map[string]bool{}
+		importPaths    = map[string]string{}
+		argumentFiles  []string
+		// dependencyModules holds the dependency modules with their source files by the module paths.
+		dependencyModules = map[string]*DependencyModule{}
 	)
 	for pkg := range packages.Postorder(pkgs) {
 		if pkg.Name == "main" {
@@ -74,59 +107,177 @@ func ResolvePackage(packageArg string) (resolved ResolvedPackage, err error) {
 				return ResolvedPackage{}, fmt.Errorf("multiple main packages found: %q and %q", mainPackageDir, pkg.Dir)
 			}
 			mainPackageDir = pkg.Dir
+			mainModule = pkg.Module
 		}
+		files := append(append([]string{}, pkg.GoFiles...), pkg.EmbedFiles...)
 		if pkg.PkgPath == "command-line-arguments" {
 			isCommandLineArguments = true
-		} else {
-			if pkg.Module == nil || !pkg.Module.Main {
-				continue
+			for _, file := range files {
+				sourceFileSet[file] = true
+				argumentFiles = append(argumentFiles, file)
 			}
+			continue
 		}
-		if pkg.Module != nil && pkg.Module.Main {
-			goModFile = pkg.Module.GoMod
-			moduleName = pkg.Module.Path
-		}
-		for _, file := range pkg.GoFiles {
-			sourceFileSet[file] = true
-		}
-		for _, pattern := range pkg.EmbedPatterns {
-			matches, err := filepath.Glob(pattern)
-			if err != nil {
-				return ResolvedPackage{}, fmt.Errorf("invalid embed pattern: %q", pattern)
+		if dependency, ok := getDependencyModule(pkg.Module); ok {
+			if dependencyModules[dependency.Path] == nil {
+				dependencyModules[dependency.Path] = &dependency
 			}
-			for _, file := range matches {
-				sourceFileSet[file] = true
+			for _, file := range append(files, pkg.OtherFiles...) {
+				dependencyModules[dependency.Path].SourceFiles = append(dependencyModules[dependency.Path].SourceFiles, file)
+				importPaths[file] = pkg.PkgPath
 			}
+			continue
+		}
+		moduleDir := getLocalModuleDir(pkg.Module)
+		if moduleDir == "" {
+			continue
+		}
+		if pkg.Module.Main {
+			mainModules = append(mainModules, pkg.Module)
+		}
+		packagePaths = append(packagePaths, pkg.PkgPath)
+		if moduleFileSets[moduleDir] == nil {
+			moduleFileSets[moduleDir] = map[string]bool{}
+		}
+		for _, file := range files {
+			moduleFileSets[moduleDir][file] = true
+			importPaths[file] = pkg.PkgPath
 		}
 	}
 	if mainPackageDir == "" {
 		return ResolvedPackage{}, fmt.Errorf("no main package found")
 	}
+	if mainModule == nil {
+		// The module of source files specified as command-line arguments is the main module containing them.
+		for _, module := range mainModules {
+			if rel, err := filepath.Rel(module.Dir, mainPackageDir); err == nil && !strings.HasPrefix(rel, "..") {
+				mainModule = module
+			}
+		}
+	}
+
+	var (
+		goModFile    string
+		moduleName   string
+		goWorkFile   string
+		localModules []LocalModule
+		rootDir      = mainPackageDir
+	)
+	if mainModule != nil && mainModule.GoMod != "" {
+		goModFile, moduleName = mainModule.GoMod, mainModule.Path
+		mainModuleDir := filepath.Dir(goModFile)
+		for file := range moduleFileSets[mainModuleDir] {
+			sourceFileSet[file] = true
+		}
+
+		if goWorkFile, err = getGoWorkFile(); err != nil {
+			return ResolvedPackage{}, err
+		}
+		localModuleDirs, err := getLocalModuleDirs(goModFile, goWorkFile)
+		if err != nil {
+			return ResolvedPackage{}, err
+		}
+		rootDirs := []string{mainModuleDir}
+		if goWorkFile != "" {
+			rootDirs = append(rootDirs, filepath.Dir(goWorkFile))
+		}
+		for _, dir := range lo.Uniq(append(localModuleDirs, lo.Keys(moduleFileSets)...)) {
+			if dir == mainModuleDir {
+				continue
+			}
+			moduleSourceFiles := lo.Keys(moduleFileSets[dir])
+			sort.Strings(moduleSourceFiles)
+			localModules = append(localModules, LocalModule{
+				Dir:         dir,
+				GoModFile:   filepath.Join(dir, "go.mod"),
+				SourceFiles: moduleSourceFiles,
+			})
+			rootDirs = append(rootDirs, dir)
+		}
+		sort.Slice(localModules, func(i, j int) bool { return localModules[i].Dir < localModules[j].Dir })
+		rootDir = commonDir(rootDirs...)
+	}
+	for _, file := range argumentFiles {
+		importPaths[file] = "command-line-arguments"
+		if goModFile != "" {
+			if rel, err := filepath.Rel(filepath.Dir(goModFile), filepath.Dir(file)); err == nil {
+				importPaths[file] = path.Join(moduleName, filepath.ToSlash(rel))
+			}
+		}
+	}
 	sourceFiles := lo.Keys(sourceFileSet)
 	sort.Strings(sourceFiles)
+
+	typedFiles := map[string]TypedFile{}
 	if isCommandLineArguments {
-		if goModFile == "" {
-			return ResolvedPackage{
-				ResolveType: ResolveType_CommandLineArguments,
-				SourceFiles: sourceFiles,
-				PackageDir:  mainPackageDir,
-			}, nil
-		} else {
-			return ResolvedPackage{
-				ResolveType: ResolveType_CommandLineArguments_Module,
-				SourceFiles: sourceFiles,
-				PackageDir:  mainPackageDir,
-				GoModFile:   goModFile,
-				Module:      moduleName,
-			}, nil
-		}
-	} else {
-		return ResolvedPackage{
-			ResolveType: ResolveType_PackageDirectory_Module,
-			SourceFiles: sourceFiles,
-			PackageDir:  mainPackageDir,
-			GoModFile:   goModFile,
-			Module:      moduleName,
-		}, nil
+		if err := loadTypedFiles(typedFiles, "", strings.Split(packageArg, ",")); err != nil {
+			return ResolvedPackage{}, err
+		}
+	}
+	if len(packagePaths) > 0 {
+		if err := loadTypedFiles(typedFiles, filepath.Dir(goModFile), packagePaths); err != nil {
+			return ResolvedPackage{}, err
+		}
+	}
+
+	var dependencies []DependencyModule
+	for _, dependency := range dependencyModules {
+		dependency.SourceFiles = lo.Uniq(dependency.SourceFiles)
+		sort.Strings(dependency.SourceFiles)
+		dependencies = append(dependencies, *dependency)
+	}
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Path < dependencies[j].Path })
+
+	resolved = ResolvedPackage{
+		SourceFiles:       sourceFiles,
+		PackageDir:        mainPackageDir,
+		GoModFile:         goModFile,
+		Module:            moduleName,
+		GoWorkFile:        goWorkFile,
+		LocalModules:      localModules,
+		RootDir:           rootDir,
+		ImportPaths:       importPaths,
+		TypedFiles:        typedFiles,
+		DependencyModules: dependencies,
+	}
+	switch {
+	case isCommandLineArguments && goModFile == "":
+		resolved.ResolveType = ResolveType_CommandLineArguments
+	case isCommandLineArguments:
+		resolved.ResolveType = ResolveType_CommandLineArguments_Module
+	default:
+		resolved.ResolveType = ResolveType_PackageDirectory_Module
+	}
+	return resolved, nil
+}
+
+// loadTypedFiles parses and type-checks the packages matching the patterns, and stores their source files into typedFiles.
+// The syntax trees and the type information of the packages loaded by go/packages are used as they are.
+// The type errors are ignored so that the partial type information is available.
+func loadTypedFiles(typedFiles map[string]TypedFile, dir string, patterns []string) error {
+	c := packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:  dir,
+		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+			f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution|parser.ParseComments)
+			if err != nil {
+				// The file is excluded and the syntax error is reported when the file is rewritten.
+				return nil, err
+			}
+			return f, nil
+		},
+	}
+	pkgs, err := packages.Load(&c, patterns...)
+	if err != nil {
+		return fmt.Errorf("failed to load packages with type information: %w", err)
+	}
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		for _, f := range pkg.Syntax {
+			typedFiles[pkg.Fset.File(f.Pos()).Name()] = TypedFile{Fset: pkg.Fset, File: f, Info: pkg.TypesInfo}
+		}
 	}
+	return nil
 }
@@ -0,0 +1,41 @@
+package internal
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CompilePackagePatterns compiles the package patterns such as ./internal/... and example.com/lib/... into regular expressions matching import paths.
+// As patterns of the go command, "..." matches any string and a pattern ending with "/..." also matches the path without it.
+// Relative patterns are resolved from the current directory into import paths in the main module.
+func CompilePackagePatterns(patterns []string, moduleName, moduleDir, cwd string) ([]*regexp.Regexp, error) {
+	regexps := []*regexp.Regexp{}
+	for _, pattern := range patterns {
+		importPathPattern := pattern
+		if pattern == "." || pattern == ".." || strings.HasPrefix(pattern, "./") || strings.HasPrefix(pattern, "../") {
+			if moduleName == "" {
+				return nil, fmt.Errorf("relative package pattern %q requires a module", pattern)
+			}
+			rel, err := filepath.Rel(moduleDir, filepath.Join(cwd, filepath.FromSlash(pattern)))
+			if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("package pattern %q is outside the main module %q", pattern, moduleDir)
+			}
+			importPathPattern = path.Join(moduleName, filepath.ToSlash(rel))
+		}
+
+		re := regexp.QuoteMeta(importPathPattern)
+		re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+		if strings.HasSuffix(re, `/.*`) {
+			re = strings.TrimSuffix(re, `/.*`) + `(/.*)?`
+		}
+		r, err := regexp.Compile(`^` + re + `$`)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile package pattern %q: %w", pattern, err)
+		}
+		regexps = append(regexps, r)
+	}
+	return regexps, nil
+}
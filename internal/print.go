@@ -0,0 +1,351 @@
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/printer"
+	"go/scanner"
+	"go/token"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+var printConfig = printer.Config{Mode: printer.UseSpaces | printer.TabIndent, Tabwidth: 8}
+
+// printFile prints the rewritten file keeping its comments and compiler directives.
+// The injected statements and declarations have no positions, so go/printer cannot place comments around them.
+// They are temporarily replaced with placeholders positioned between the original nodes,
+// and the placeholders are substituted with the printed injected nodes after printing the file.
+// If the file has a name, //line directives are emitted so that the rewritten code is mapped to the original source.
+func (x *Xtrace) printFile(f *ast.File) ([]byte, error) {
+	injected := map[string]ast.Node{}
+	newPlaceholder := func(node ast.Node) string {
+		name := fmt.Sprintf("placeholder_%d_%s", len(injected)+1, x.UniqueString)
+		injected[name] = node
+		return name
+	}
+
+	// The statements in the injected blocks such as the function literal of logGo are printed as they are.
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.File:
+			x.placeholdDecls(node, newPlaceholder)
+		case *ast.BlockStmt:
+			if node.Lbrace.IsValid() {
+				x.placeholdStmts(node.List, node.Lbrace+1, node.Rbrace, newPlaceholder)
+			}
+		case *ast.CaseClause:
+			if node.Colon.IsValid() {
+				x.placeholdStmts(node.Body, node.Colon+1, token.NoPos, newPlaceholder)
+			}
+		case *ast.CommClause:
+			if node.Colon.IsValid() {
+				x.placeholdStmts(node.Body, node.Colon+1, token.NoPos, newPlaceholder)
+			}
+		}
+		return true
+	})
+
+	buf := bytes.NewBuffer(nil)
+	if err := printConfig.Fprint(buf, x.fset, f); err != nil {
+		return nil, fmt.Errorf("failed to print: %w", err)
+	}
+	printedLines := strings.SplitAfter(buf.String(), "\n")
+	printedInsideTokens := linesInsideTokens(buf.String())
+
+	filename := x.fset.File(f.Pos()).Name()
+	originalPositions, err := x.originalPositions(f, filename, printedLines)
+	if err != nil {
+		return nil, err
+	}
+
+	// A //line directive is emitted where the position the compiler recognizes differs from the original position,
+	// that is, before and after the injected code and where the indentation is changed.
+	// The injected code is mapped to the position of the last original code before it.
+	// No directive is emitted in a raw string literal or a general comment spanning multiple lines not to change them.
+	dst, compilerLine, lastOriginalPosition := bytes.NewBuffer(nil), 1, sourcePosition{line: 1, column: 1}
+	writeLine := func(text string, originalPosition sourcePosition, insideToken, isInjected bool) bool {
+		trimmed := strings.TrimSpace(text)
+		isCode := !insideToken && strings.Trim(trimmed, "{}()") != "" && !strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "/*")
+		indentWidth := len(text) - len(strings.TrimLeft(text, " \t"))
+		if isInjected {
+			// The injected code has no original columns, so its indentation is kept.
+			originalPosition.column = max(originalPosition.column, indentWidth+1)
+		}
+		if originalPositions != nil && isCode && (originalPosition.line != compilerLine || originalPosition.column != indentWidth+1) {
+			// The column of the directive is the column of the first character of the next line, which may be an indentation.
+			if excess := indentWidth + 1 - originalPosition.column; excess > 0 {
+				text, indentWidth = text[excess:], indentWidth-excess
+			}
+			// //line path/to/source.go:123:4
+			fmt.Fprintf(dst, "//line %s:%d:%d\n", filename, originalPosition.line, originalPosition.column-indentWidth)
+			compilerLine = originalPosition.line
+		}
+		dst.WriteString(text)
+		compilerLine += strings.Count(text, "\n")
+		return isCode
+	}
+
+	var printErr error
+	placeholderRegexp := regexp.MustCompile(`(?:var )?(placeholder_\d+_` + regexp.QuoteMeta(x.UniqueString) + `)(?: int)?`)
+	for i, printed := range printedLines {
+		originalPosition := sourcePosition{}
+		if originalPositions != nil {
+			originalPosition = originalPositions[i]
+		}
+		if !placeholderRegexp.MatchString(printed) {
+			if writeLine(printed, originalPosition, printedInsideTokens[i], false) {
+				lastOriginalPosition = originalPosition
+			}
+			continue
+		}
+		indent := printed[:len(printed)-len(strings.TrimLeft(printed, " \t"))]
+		replaced := placeholderRegexp.ReplaceAllStringFunc(printed, func(match string) string {
+			node := injected[placeholderRegexp.FindStringSubmatch(match)[1]]
+			buf := bytes.NewBuffer(nil)
+			if err := printConfig.Fprint(buf, x.fset, node); err != nil {
+				printErr = err
+			}
+			return indentLines(buf.String(), indent)
+		})
+		replacedInsideTokens := linesInsideTokens(replaced)
+		for j, text := range strings.SplitAfter(replaced, "\n") {
+			writeLine(text, lastOriginalPosition, replacedInsideTokens[j], true)
+		}
+	}
+	if printErr != nil {
+		return nil, fmt.Errorf("failed to print: %w", printErr)
+	}
+
+	return dst.Bytes(), nil
+}
+
+// sourcePosition is a line and a column in bytes of the original source, which start at 1.
+type sourcePosition struct {
+	line, column int
+}
+
+// originalPositions returns the positions in the original source corresponding to the beginnings of the printed lines of the file.
+// It returns nil if the file has no name, and an error if the printed lines cannot be mapped to the original source.
+func (x *Xtrace) originalPositions(f *ast.File, filename string, printedLines []string) ([]sourcePosition, error) {
+	if filename == "" {
+		return nil, nil
+	}
+	// Build constraints are excluded because go/printer moves them after printing, which breaks the line directives.
+	withoutConstraints := *f
+	withoutConstraints.Comments = nil
+	for _, c := range f.Comments {
+		isConstraint := lo.SomeBy(c.List, func(comment *ast.Comment) bool {
+			return constraint.IsGoBuild(comment.Text) || constraint.IsPlusBuild(comment.Text)
+		})
+		if !isConstraint {
+			withoutConstraints.Comments = append(withoutConstraints.Comments, c)
+		}
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := (&printer.Config{Mode: printer.RawFormat | printer.SourcePos, Tabwidth: 8}).Fprint(buf, x.fset, &withoutConstraints); err != nil {
+		return nil, fmt.Errorf("failed to print: %w", err)
+	}
+
+	// The lines printed with SourcePos differ from the printed lines only in whitespaces, blank lines, and build constraints.
+	type sourceLine struct {
+		text string
+		line int
+	}
+	sourceLines, line := []sourceLine{}, 1
+	lineDirectivePrefix := "//line " + filename + ":"
+	for _, printed := range strings.SplitAfter(buf.String(), "\n") {
+		if strings.HasPrefix(printed, lineDirectivePrefix) {
+			if n, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(printed, lineDirectivePrefix))); err == nil {
+				line = n
+				continue
+			}
+		}
+		sourceLines = append(sourceLines, sourceLine{text: strings.Join(strings.Fields(printed), " "), line: line})
+		line++
+	}
+
+	positions, j := make([]sourcePosition, len(printedLines)), 0
+	for i, printed := range printedLines {
+		text := strings.Join(strings.Fields(printed), " ")
+		for j < len(sourceLines) && sourceLines[j].text != text && sourceLines[j].text == "" {
+			j++
+		}
+		switch {
+		case j < len(sourceLines) && sourceLines[j].text == text:
+			positions[i] = sourcePosition{line: sourceLines[j].line, column: x.originalColumn(sourceLines[j].line, text)}
+			j++
+		case text == "" || strings.HasPrefix(text, "//") || strings.HasPrefix(text, "/*"):
+			positions[i] = sourcePosition{line: 1, column: 1}
+			if i > 0 {
+				positions[i].line = positions[i-1].line + 1
+			}
+		default:
+			return nil, fmt.Errorf("failed to map line %d of the rewritten code to the original source: %q", i+1, text)
+		}
+	}
+	return positions, nil
+}
+
+// originalColumn returns the column of the text in the line of the original source.
+// If the text is not found, the column of the first non-blank character of the line is returned.
+func (x *Xtrace) originalColumn(line int, text string) int {
+	file := x.fset.File(x.file.Package)
+	if line < 1 || line > file.LineCount() {
+		return 1
+	}
+	sourceLine := x.src[x.offset(file.LineStart(line)):]
+	if end := bytes.IndexByte(sourceLine, '\n'); end >= 0 {
+		sourceLine = sourceLine[:end]
+	}
+	if fields := strings.Fields(text); len(fields) > 0 {
+		if index := bytes.Index(sourceLine, []byte(fields[0])); index >= 0 {
+			return index + 1
+		}
+	}
+	return len(sourceLine) - len(bytes.TrimLeft(sourceLine, " \t")) + 1
+}
+
+// linesInsideTokens reports whether each line of the code begins inside a token spanning multiple lines,
+// that is, a raw string literal or a general comment, in which nothing can be inserted without changing the token.
+func linesInsideTokens(code string) []bool {
+	inside := make([]bool, strings.Count(code, "\n")+1)
+	file := token.NewFileSet().AddFile("", -1, len(code))
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok != token.STRING && tok != token.COMMENT {
+			continue
+		}
+		begin := file.Line(pos)
+		for line := begin + 1; line <= begin+strings.Count(lit, "\n"); line++ {
+			inside[line-1] = true
+		}
+	}
+	return inside
+}
+
+// indentLines indents the lines of the code except the first line and the lines beginning inside raw string literals or general comments.
+func indentLines(code string, indent string) string {
+	insideTokens := linesInsideTokens(code)
+	lines := strings.SplitAfter(code, "\n")
+	for i := 1; i < len(lines); i++ {
+		if !insideTokens[i] {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func (x *Xtrace) placeholdDecls(f *ast.File, newPlaceholder func(ast.Node) string) {
+	positions := placeholderPositions(x, f.Decls, f.Name.End(), token.NoPos)
+	for i, pos := range positions {
+		if pos.IsValid() {
+			// var placeholder_1_abcdefgh int
+			f.Decls[i] = &ast.GenDecl{
+				TokPos: pos,
+				Tok:    token.VAR,
+				Specs: []ast.Spec{&ast.ValueSpec{
+					Names: []*ast.Ident{{NamePos: pos, Name: newPlaceholder(f.Decls[i])}},
+					Type:  &ast.Ident{NamePos: pos, Name: "int"},
+				}},
+			}
+		}
+	}
+}
+
+func (x *Xtrace) placeholdStmts(stmts []ast.Stmt, from, to token.Pos, newPlaceholder func(ast.Node) string) {
+	positions := placeholderPositions(x, stmts, from, to)
+	for i, pos := range positions {
+		if pos.IsValid() {
+			// placeholder_1_abcdefgh
+			stmts[i] = &ast.ExprStmt{X: &ast.Ident{NamePos: pos, Name: newPlaceholder(stmts[i])}}
+		}
+	}
+}
+
+// placeholderPositions returns the positions of placeholders for the injected nodes in the list, which are the nodes without positions.
+// The position is NoPos for the original nodes.
+func placeholderPositions[N ast.Node](x *Xtrace, nodes []N, from, to token.Pos) []token.Pos {
+	positions := make([]token.Pos, len(nodes))
+	for i, node := range nodes {
+		if node.Pos().IsValid() {
+			continue
+		}
+		var prev, next ast.Node
+		for j := i - 1; j >= 0 && prev == nil; j-- {
+			if nodes[j].Pos().IsValid() {
+				prev = nodes[j]
+			}
+		}
+		for j := i + 1; j < len(nodes) && next == nil; j++ {
+			if nodes[j].Pos().IsValid() {
+				next = nodes[j]
+			}
+		}
+		positions[i] = x.placeholderPos(prev, next, from, to)
+	}
+	return positions
+}
+
+// placeholderPos returns the position of a placeholder which is placed after prev and before next.
+// The placeholder is placed at the end of a line so that trailing comments of prev are kept before the placeholder
+// and comments leading next are kept after the placeholder.
+func (x *Xtrace) placeholderPos(prev, next ast.Node, from, to token.Pos) token.Pos {
+	lower := from
+	if prev != nil {
+		// The end of the node is invalid if it ends with injected code.
+		lower = prev.Pos()
+		if prev.End() > lower {
+			lower = prev.End()
+		}
+	}
+	file := x.fset.File(lower)
+	if next != nil {
+		upper := x.leadingCommentPos(next, lower)
+		if line := file.Line(upper); line > 1 {
+			return max(file.LineStart(line)-1, lower)
+		}
+		return lower
+	}
+	pos := token.Pos(file.Base() + file.Size())
+	if line := file.Line(lower); line < file.LineCount() {
+		pos = file.LineStart(line+1) - 1
+	}
+	if to.IsValid() && to < pos {
+		return to
+	}
+	return pos
+}
+
+// leadingCommentPos returns the beginning of the comments which lead the node without blank lines.
+func (x *Xtrace) leadingCommentPos(node ast.Node, lower token.Pos) token.Pos {
+	pos := node.Pos()
+	file := x.fset.File(pos)
+	comments := x.file.Comments
+	i := sort.Search(len(comments), func(i int) bool { return comments[i].End() > pos }) - 1
+	for ; i >= 0; i-- {
+		c := comments[i]
+		if c.Pos() < lower || file.Line(c.End()) < file.Line(pos)-1 || !x.startsLine(c.Pos()) {
+			break
+		}
+		pos = c.Pos()
+	}
+	return pos
+}
+
+func (x *Xtrace) startsLine(pos token.Pos) bool {
+	file := x.fset.File(pos)
+	lineStart := file.LineStart(file.Line(pos))
+	return strings.TrimSpace(x.fragment(lineStart, pos)) == ""
+}
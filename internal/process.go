@@ -1,36 +1,69 @@
 package internal
 
 import (
-	"bytes"
 	"fmt"
 	"go/ast"
 	"go/parser"
-	"go/printer"
 	"go/token"
 
 	"github.com/samber/lo/mutable"
 	"golang.org/x/tools/go/ast/astutil"
 )
 
+// ProcessCode parses the source file, type-checks it alone, and rewrites it.
 func ProcessCode(config Config, filename string, src []byte) (dst []byte, err error) {
 	fset := token.NewFileSet()
-	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution)
+	f, err := parser.ParseFile(fset, filename, src, parser.SkipObjectResolution|parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse: %w", err)
 	}
+	return ProcessTypedCode(config, TypedFile{Fset: fset, File: f, Info: CheckTypes(fset, f)}, src)
+}
 
+// ProcessTypedCode rewrites the source file which has been parsed and type-checked with its package.
+// The file must have been parsed from src with parser.SkipObjectResolution and parser.ParseComments.
+func ProcessTypedCode(config Config, typed TypedFile, src []byte) (dst []byte, err error) {
+	fset, f := typed.Fset, typed.File
 	x := &Xtrace{
 		Config: config,
 		fset:   fset,
+		file:   f,
 		src:    src,
+		info:   typed.Info,
 
 		funcByBody:   CollectFuncInfo(f),
 		forByBody:    CollectForInfo(f),
 		caseByBody:   CollectCaseInfo(f),
 		ifElseByBody: CollectIfElseInfo(f),
+		commOps:      CollectCommOperations(f),
+		chanRanges:   CollectChanRanges(f, typed.Info),
+
+		externPackages: CollectExternPackages(f, config.ModuleName),
 	}
+	x.directives = x.collectDirectives()
+
+	astutil.Apply(f, func(c *astutil.Cursor) bool {
+		// Functions and package-level declarations which are not traced are skipped with their children.
+		switch node := c.Node().(type) {
+		case *ast.FuncDecl:
+			if !x.isFuncTraced(x.funcName(node)) {
+				return false
+			}
+		case *ast.GenDecl:
+			if _, isFile := c.Parent().(*ast.File); isFile {
+				if !x.isFuncTraced(x.funcName(nil)) {
+					return false
+				}
+			}
+		}
+		x.enterNode(c.Node())
+		return true
+	}, func(c *astutil.Cursor) bool {
+		defer x.leaveNode(c.Node())
+		if x.isTraceOff() {
+			return true
+		}
 
-	astutil.Apply(f, nil, func(c *astutil.Cursor) bool {
 		switch node := c.Node().(type) {
 		case *ast.GenDecl:
 			switch node.Tok {
@@ -40,6 +73,12 @@ func ProcessCode(config Config, filename string, src []byte) (dst []byte, err er
 					x.logFileVariable(c, node)
 				}
 			}
+		case *ast.CallExpr:
+			x.logRecover(c, node)
+			x.logClose(c, node)
+			x.logExternCall(c, node)
+		case *ast.UnaryExpr:
+			x.logRecv(c, node)
 		case *ast.FuncLit, *ast.FuncDecl:
 			var results *ast.FieldList
 			switch node := node.(type) {
@@ -49,11 +88,14 @@ func ProcessCode(config Config, filename string, src []byte) (dst []byte, err er
 				results = node.Type.Results
 			}
 			if results != nil {
+				if !results.Opening.IsValid() {
+					results.Opening, results.Closing = results.Pos(), results.End()
+				}
 				count := 0
 				for _, param := range results.List {
 					if len(param.Names) == 0 {
 						count++
-						param.Names = []*ast.Ident{ast.NewIdent(fmt.Sprintf("return_%d_%s", count, x.UniqueString))}
+						param.Names = []*ast.Ident{{NamePos: param.Type.Pos(), Name: fmt.Sprintf("return_%d_%s", count, x.UniqueString)}}
 					} else {
 						for _, name := range param.Names {
 							count++
@@ -76,6 +118,7 @@ func ProcessCode(config Config, filename string, src []byte) (dst []byte, err er
 					x.logForVariables(c, info)
 				}
 				if info, ok := x.caseByBody[node]; ok {
+					x.logCaseVariables(c, info)
 					x.logCaseStatement(c, info)
 				}
 				if info, ok := x.ifElseByBody[node]; ok {
@@ -93,28 +136,36 @@ func ProcessCode(config Config, filename string, src []byte) (dst []byte, err er
 				}
 			case *ast.AssignStmt:
 				if _, ok := c.Parent().(*ast.BlockStmt); ok {
-					if node.Tok == token.ASSIGN || node.Tok == token.DEFINE {
-						x.logLocalAssignment(c, node)
-					}
+					// =, :=, and compound assignments such as += and <<=
+					x.logLocalAssignment(c, node)
 				}
 			case *ast.EmptyStmt:
 			case *ast.BlockStmt:
 			case *ast.ExprStmt:
 			case *ast.IfStmt:
+				x.logIfCondition(c, node)
 			case *ast.SwitchStmt:
+				x.logSwitchTag(c, node)
 			case *ast.TypeSwitchStmt:
+				x.logTypeSwitchType(c, node)
 			case *ast.CaseClause:
 			case *ast.SelectStmt:
 			case *ast.CommClause:
 			case *ast.ForStmt:
 			case *ast.RangeStmt:
+				x.logRangeRecv(c, node)
 			case *ast.ReturnStmt:
 			case *ast.DeferStmt:
 			case *ast.GoStmt:
+				x.logGo(c, node)
 			case *ast.BranchStmt:
 			case *ast.LabeledStmt:
 			case *ast.SendStmt:
+				x.logSend(c, node)
 			case *ast.IncDecStmt:
+				if _, ok := c.Parent().(*ast.BlockStmt); ok {
+					x.logLocalIncDec(c, node)
+				}
 			}
 		}
 
@@ -125,12 +176,7 @@ func ProcessCode(config Config, filename string, src []byte) (dst []byte, err er
 		astutil.AddImport(fset, f, config.LibraryImportPath())
 	}
 
-	buf := bytes.NewBuffer(nil)
-	if err := printer.Fprint(buf, fset, f); err != nil {
-		return nil, fmt.Errorf("failed to print: %w", err)
-	}
-
-	return buf.Bytes(), nil
+	return x.printFile(f)
 }
 
 type FuncInfo struct {
@@ -229,8 +275,30 @@ func CollectForInfo(f *ast.File) (forByBody map[ast.Stmt]*ForInfo) {
 }
 
 type CaseInfo struct {
-	Case *ast.CaseClause
-	Comm *ast.CommClause
+	Case       *ast.CaseClause
+	Comm       *ast.CommClause
+	TypeSwitch *ast.TypeSwitchStmt
+}
+
+// Variables returns the variable bound by the type switch in the case clause or the variables receiving in the comm clause.
+func (i CaseInfo) Variables() (vars []*ast.Ident) {
+	if ts := i.TypeSwitch; ts != nil {
+		if assign, ok := ts.Assign.(*ast.AssignStmt); ok {
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok && ident.Name != "_" {
+				vars = append(vars, ident)
+			}
+		}
+	}
+	if c := i.Comm; c != nil {
+		if assign, ok := c.Comm.(*ast.AssignStmt); ok {
+			for _, lhs := range assign.Lhs {
+				if ident, ok := lhs.(*ast.Ident); ok && ident.Name != "_" {
+					vars = append(vars, ident)
+				}
+			}
+		}
+	}
+	return vars
 }
 
 func (i CaseInfo) CaseLabel() (begin, end token.Pos) {
@@ -248,7 +316,12 @@ func CollectCaseInfo(f *ast.File) (caseByBody map[ast.Stmt]*CaseInfo) {
 	ast.PreorderStack(f, nil, func(n ast.Node, s []ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.CaseClause:
-			caseByBody[node] = &CaseInfo{Case: node}
+			info := &CaseInfo{Case: node}
+			if len(s) >= 2 {
+				// The parent of the case clause is the body of the switch statement.
+				info.TypeSwitch, _ = s[len(s)-2].(*ast.TypeSwitchStmt)
+			}
+			caseByBody[node] = info
 		case *ast.CommClause:
 			caseByBody[node] = &CaseInfo{Comm: node}
 		}
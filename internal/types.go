@@ -0,0 +1,155 @@
+package internal
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strconv"
+)
+
+// errorInterface is the underlying interface of the predeclared error type.
+var errorInterface = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// CheckTypes type-checks the file and returns the type information.
+// The type errors are ignored so that the partial type information is available even if the file refers to the other files of the package.
+func CheckTypes(fset *token.FileSet, f *ast.File) *types.Info {
+	info := newTypesInfo()
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "gc", nil),
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(f.Name.Name, fset, []*ast.File{f}, info)
+	return info
+}
+
+func newTypesInfo() *types.Info {
+	return &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+}
+
+// isPackageName reports whether the identifier refers to an imported package.
+// It reports true if the type information is not available.
+func (x *Xtrace) isPackageName(ident *ast.Ident) bool {
+	if x.info == nil {
+		return true
+	}
+	obj, ok := x.info.Uses[ident]
+	if !ok {
+		return true
+	}
+	_, ok = obj.(*types.PkgName)
+	return ok
+}
+
+// isConstant reports whether the expression is a constant such as a literal or a named constant.
+// A constant may be untyped, so it must not be assigned to a temporary variable which would be typed by its default type.
+func (x *Xtrace) isConstant(expr ast.Expr) bool {
+	if x.info == nil {
+		return false
+	}
+	return x.info.Types[expr].Value != nil
+}
+
+// isUntyped reports whether the expression is an untyped non-constant value such as 1<<s and a == b,
+// whose type is determined by the context, so it must be typed explicitly when it is assigned to a temporary variable.
+func (x *Xtrace) isUntyped(expr ast.Expr) bool {
+	if x.isConstant(expr) {
+		return false
+	}
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return x.isUntyped(expr.X)
+	case *ast.UnaryExpr:
+		return expr.Op != token.AND && expr.Op != token.ARROW && x.isUntyped(expr.X)
+	case *ast.BinaryExpr:
+		switch expr.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			return true
+		case token.SHL, token.SHR:
+			return x.isConstant(expr.X) || x.isUntyped(expr.X)
+		default:
+			return (x.isConstant(expr.X) || x.isUntyped(expr.X)) && (x.isConstant(expr.Y) || x.isUntyped(expr.Y))
+		}
+	default:
+		return false
+	}
+}
+
+// resultCount returns the number of the results if the expression is a call returning multiple values, or 1 otherwise.
+func (x *Xtrace) resultCount(expr ast.Expr) int {
+	if x.info == nil {
+		return 1
+	}
+	if tuple, ok := x.info.TypeOf(expr).(*types.Tuple); ok {
+		return tuple.Len()
+	}
+	return 1
+}
+
+// typeExprOf returns the type expression of the type of the expression which can be written in the file.
+// It reports false if the type refers to a package which is not imported by the file.
+func (x *Xtrace) typeExprOf(expr ast.Expr) (ast.Expr, bool) {
+	if x.info == nil || x.info.TypeOf(expr) == nil {
+		return nil, false
+	}
+	qualified := true
+	typeString := types.TypeString(x.info.TypeOf(expr), func(pkg *types.Package) string {
+		for _, spec := range x.file.Imports {
+			if importPath, err := strconv.Unquote(spec.Path.Value); err == nil && importPath == pkg.Path() {
+				switch {
+				case spec.Name == nil:
+					return pkg.Name()
+				case spec.Name.Name == ".":
+					return ""
+				case spec.Name.Name != "_":
+					return spec.Name.Name
+				}
+			}
+		}
+		if pkg.Name() != x.file.Name.Name {
+			qualified = false
+		}
+		return ""
+	})
+	if !qualified {
+		return nil, false
+	}
+	typeExpr, err := parser.ParseExpr(typeString)
+	if err != nil {
+		return nil, false
+	}
+	return cloneNode(typeExpr), true
+}
+
+// isGenericFunc reports whether the identifier refers to a generic function, which cannot be used as a value without instantiation.
+func (x *Xtrace) isGenericFunc(ident *ast.Ident) bool {
+	if x.info == nil {
+		return false
+	}
+	fn, ok := x.info.Uses[ident].(*types.Func)
+	return ok && fn.Signature().TypeParams().Len() > 0
+}
+
+// isFunc reports whether the identifier refers to a function, excluding types converting values and variables of function types.
+func (x *Xtrace) isFunc(ident *ast.Ident) bool {
+	if x.info == nil {
+		return false
+	}
+	_, ok := x.info.Uses[ident].(*types.Func)
+	return ok
+}
+
+// isErrorType reports whether the expression is of a type implementing error,
+// such as error itself and a concrete error type like *MyErr whose Error method has a pointer receiver.
+func (x *Xtrace) isErrorType(expr ast.Expr) bool {
+	if x.info == nil {
+		return false
+	}
+	t := x.info.TypeOf(expr)
+	return t != nil && types.Implements(t, errorInterface)
+}
@@ -0,0 +1,136 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LocalModule is a module other than the main module whose source files are on the local file system,
+// that is, a module used in the workspace or a module replaced with a local directory.
+type LocalModule struct {
+	Dir         string
+	GoModFile   string
+	SourceFiles []string
+}
+
+// getGoWorkFile returns the path to go.work of the workspace containing the current directory.
+// It returns an empty string if the go command does not run in workspace mode.
+func getGoWorkFile() (string, error) {
+	out, err := exec.Command("go", "env", "GOWORK").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run go env GOWORK: %w", err)
+	}
+	goWorkFile := strings.TrimSpace(string(out))
+	if goWorkFile == "off" {
+		return "", nil
+	}
+	return goWorkFile, nil
+}
+
+type replaceJSON struct {
+	New struct {
+		Path    string
+		Version string
+	}
+}
+
+// getLocalModuleDirs returns the directories of the modules used in the workspace and the local directories replacing modules.
+func getLocalModuleDirs(goModFile, goWorkFile string) (dirs []string, err error) {
+	if goWorkFile != "" {
+		var goWork struct {
+			Use     []struct{ DiskPath string }
+			Replace []replaceJSON
+		}
+		if err := execGoEditJSON(&goWork, "work", goWorkFile); err != nil {
+			return nil, err
+		}
+		for _, use := range goWork.Use {
+			dirs = append(dirs, resolveLocalPath(filepath.Dir(goWorkFile), use.DiskPath))
+		}
+		for _, replace := range goWork.Replace {
+			if isLocalReplace(replace) {
+				dirs = append(dirs, resolveLocalPath(filepath.Dir(goWorkFile), replace.New.Path))
+			}
+		}
+	}
+	if goModFile != "" {
+		var goMod struct {
+			Replace []replaceJSON
+		}
+		if err := execGoEditJSON(&goMod, "mod", goModFile); err != nil {
+			return nil, err
+		}
+		for _, replace := range goMod.Replace {
+			if isLocalReplace(replace) {
+				dirs = append(dirs, resolveLocalPath(filepath.Dir(goModFile), replace.New.Path))
+			}
+		}
+	}
+	return dirs, nil
+}
+
+// execGoEditJSON decodes the output of go mod edit -json or go work edit -json.
+func execGoEditJSON(v any, subcommand string, file string) error {
+	out, err := exec.Command("go", subcommand, "edit", "-json", file).Output()
+	if err != nil {
+		return fmt.Errorf("failed to run go %s edit -json %s: %w", subcommand, file, err)
+	}
+	if err := json.Unmarshal(out, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", file, err)
+	}
+	return nil
+}
+
+// isLocalReplace reports whether the replacement is a local directory, which is a path without a version.
+func isLocalReplace(replace replaceJSON) bool {
+	return replace.New.Version == "" && (filepath.IsAbs(replace.New.Path) || replace.New.Path == "." || replace.New.Path == ".." ||
+		strings.HasPrefix(replace.New.Path, "./") || strings.HasPrefix(replace.New.Path, "../"))
+}
+
+func resolveLocalPath(baseDir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(path))
+}
+
+// getLocalModuleDir returns the directory of the module if its source files are on the local file system.
+func getLocalModuleDir(module *packages.Module) string {
+	switch {
+	case module == nil:
+		return ""
+	case module.Replace != nil:
+		if module.Replace.Version == "" {
+			return module.Replace.Dir
+		}
+		return ""
+	case module.Main:
+		return module.Dir
+	default:
+		return ""
+	}
+}
+
+// commonDir returns the deepest directory containing all the directories.
+func commonDir(dirs ...string) string {
+	common := dirs[0]
+	for _, dir := range dirs[1:] {
+		for {
+			rel, err := filepath.Rel(common, dir)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				break
+			}
+			parent := filepath.Dir(common)
+			if parent == common {
+				break
+			}
+			common = parent
+		}
+	}
+	return common
+}
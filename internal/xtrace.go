@@ -1,46 +1,182 @@
 package internal
 
 import (
+	"bytes"
+	"fmt"
 	"go/ast"
+	"go/printer"
 	"go/token"
+	"go/types"
 	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
 )
 
 type Xtrace struct {
 	Config
 	fset *token.FileSet
+	file *ast.File
 	src  []byte
+	info *types.Info
 
 	funcByBody   map[ast.Stmt]*FuncInfo
 	forByBody    map[ast.Stmt]*ForInfo
 	caseByBody   map[ast.Stmt]*CaseInfo
 	ifElseByBody map[ast.Stmt]*IfElseInfo
+	commOps      map[ast.Node]bool
+	chanRanges   map[*ast.RangeStmt]bool
+
+	externPackages map[string]bool
+
+	directives      map[ast.Node][]Directive
+	directiveScopes []directiveScope
+
+	// sources holds the source code of the expressions replaced with the injected expressions.
+	sources map[ast.Expr]string
 
 	libraryRequired bool
+	tempCount       int
+}
+
+// offset returns the offset of the position in the source, where the file set may contain the other files.
+func (x *Xtrace) offset(pos token.Pos) int {
+	return int(pos) - x.fset.File(x.file.Package).Base()
 }
 
 func (x *Xtrace) fragment(pos, end token.Pos) string {
-	return string(x.src[pos-1 : end-1])
+	return string(x.src[x.offset(pos):x.offset(end)])
 }
 func (x *Xtrace) fragmentLine(pos token.Pos) string {
-	begin := pos - 1
+	begin := x.offset(pos)
 	for ; begin > 0; begin-- {
 		if x.src[begin-1] == '\n' || x.src[begin-1] == '\r' {
 			break
 		}
 	}
-	end := pos
-	for ; end < token.Pos(len(x.src)); end++ {
+	end := x.offset(pos) + 1
+	for ; end < len(x.src); end++ {
 		if x.src[end] == '\n' || x.src[end] == '\r' {
 			break
 		}
 	}
-	frag := x.fragment(begin+1, end+1)
+	frag := string(x.src[begin:end])
 	frag, _, _ = strings.Cut(frag, "\n")
 	frag, _, _ = strings.Cut(frag, "\r")
 	return frag
 }
 
+// sourceOf returns the source code of the expression.
+// If the expression has been replaced with injected code, the injected code is printed instead.
+func (x *Xtrace) sourceOf(expr ast.Expr) string {
+	if source, ok := x.sources[expr]; ok {
+		return source
+	}
+	if expr.Pos().IsValid() {
+		return x.fragment(expr.Pos(), expr.End())
+	}
+	buf := bytes.NewBuffer(nil)
+	_ = printer.Fprint(buf, x.fset, expr)
+	return buf.String()
+}
+
+// injectExpr returns the injected expression which replaces the original expression remembering the source code of the original one.
+// The injected function call spans the range of the original expression so that the expressions and statements containing it keep their ranges.
+func (x *Xtrace) injectExpr(original, injected ast.Expr) ast.Expr {
+	if x.sources == nil {
+		x.sources = map[ast.Expr]string{}
+	}
+	x.sources[injected] = x.sourceOf(original)
+	if call, ok := injected.(*ast.CallExpr); ok {
+		spanRange(call, original)
+	}
+	return injected
+}
+
+// spanRange makes the injected function call span the range of the original node.
+func spanRange(call *ast.CallExpr, original ast.Node) {
+	if fun, ok := call.Fun.(*ast.Ident); ok && original.Pos().IsValid() && original.End().IsValid() {
+		fun.NamePos, call.Rparen = original.Pos(), original.End()-1
+	}
+}
+
+// assignedExprs returns the operands to which the values of the right-hand side are assigned by the parent,
+// such as v and ok of v, ok := <-ch and var v, ok = <-ch.
+func assignedExprs(parent ast.Node) []ast.Expr {
+	switch parent := parent.(type) {
+	case *ast.AssignStmt:
+		return parent.Lhs
+	case *ast.ValueSpec:
+		exprs := []ast.Expr{}
+		for _, name := range parent.Names {
+			exprs = append(exprs, name)
+		}
+		return exprs
+	default:
+		return nil
+	}
+}
+
+// newTempName returns a unique name of a temporary variable which holds a value evaluated in injected code.
+func (x *Xtrace) newTempName() string {
+	x.tempCount++
+	return fmt.Sprintf("tmp_%d_%s", x.tempCount, x.UniqueString)
+}
+
+// hoistSideEffects replaces the function calls and receive operations in the expression with temporary variables
+// which are assigned before the statement at the cursor, so that the expression can be evaluated again without side effects.
+// The operands of && and || are evaluated conditionally, so the whole logical expression containing side effects is hoisted.
+func (x *Xtrace) hoistSideEffects(c *astutil.Cursor, expr ast.Expr) ast.Expr {
+	return astutil.Apply(expr, func(c2 *astutil.Cursor) bool {
+		switch node := c2.Node().(type) {
+		case *ast.BinaryExpr:
+			if (node.Op != token.LAND && node.Op != token.LOR) || !hasSideEffects(node) {
+				return true
+			}
+		case *ast.FuncLit:
+			return false
+		default:
+			if !isSideEffect(node) {
+				return true
+			}
+		}
+		// tmp_1_abcdefgh := f()
+		temp := x.newTempName()
+		c.InsertBefore(&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(temp)},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{c2.Node().(ast.Expr)},
+		})
+		c2.Replace(x.injectExpr(c2.Node().(ast.Expr), ast.NewIdent(temp)))
+		return false
+	}, nil).(ast.Expr)
+}
+
+// isSideEffect reports whether the node is a function call or a receive operation, excluding len and cap.
+func isSideEffect(node ast.Node) bool {
+	switch node := node.(type) {
+	case *ast.CallExpr:
+		ident, ok := node.Fun.(*ast.Ident)
+		return !ok || (ident.Name != "len" && ident.Name != "cap")
+	case *ast.UnaryExpr:
+		return node.Op == token.ARROW
+	default:
+		return false
+	}
+}
+
+// hasSideEffects reports whether the expression contains function calls or receive operations outside function literals.
+func hasSideEffects(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if _, ok := n.(*ast.FuncLit); ok {
+			return false
+		}
+		found = found || isSideEffect(n)
+		return !found
+	})
+	return found
+}
+
 func (x *Xtrace) IdentShowTimestamp() string {
 	if x.ShowTimestamp {
 		return "true"